@@ -0,0 +1,110 @@
+package structgraphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+// a Transport sends the HTTP requests of a Client, e.g. an *http.Client. tests can use the client of an httptest.Server
+type Transport interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// a Client sends graphql operations to a server over HTTP. it is used by the functions generated by GenerateClient
+type Client struct {
+	url       string
+	transport Transport
+	header    http.Header
+}
+
+type ClientOption func(*Client)
+
+// send requests with transport instead of http.DefaultClient
+func WithTransport(transport Transport) ClientOption {
+	return func(client *Client) { client.transport = transport }
+}
+
+// add a header to every request, e.g. for authorization
+func WithHeader(key, value string) ClientOption {
+	return func(client *Client) { client.header.Add(key, value) }
+}
+
+func NewClient(url string, opts ...ClientOption) *Client {
+	var client Client
+	client.url = url
+	client.transport = http.DefaultClient
+	client.header = make(http.Header)
+	for _, opt := range opts {
+		opt(&client)
+	}
+	return &client
+}
+
+// ResponseError holds the errors a server responded with. the data it could still resolve is decoded nonetheless
+type ResponseError struct {
+	Errors []gqlerrors.FormattedError
+}
+
+func (err *ResponseError) Error() string {
+	var messages []string
+	for _, e := range err.Errors {
+		messages = append(messages, e.Message)
+	}
+	return "graphql: " + strings.Join(messages, "; ")
+}
+
+// send query with variables, which are encoded as JSON, and decode the data of the response into result
+func (client *Client) Do(ctx context.Context, query string, variables interface{}, result interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range client.header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	res, err := client.transport.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	var response struct {
+		Data   json.RawMessage            `json:"data"`
+		Errors []gqlerrors.FormattedError `json:"errors"`
+	}
+	// servers may report errors with other statuses than 200 as long as the body is a graphql response
+	if err := json.Unmarshal(raw, &response); err != nil {
+		if res.StatusCode != http.StatusOK {
+			return fmt.Errorf("graphql: unexpected status %v", res.Status)
+		}
+		return fmt.Errorf("graphql: cannot decode response: %v", err)
+	}
+	if len(response.Data) > 0 && string(response.Data) != "null" && result != nil {
+		if err := json.Unmarshal(response.Data, result); err != nil {
+			return fmt.Errorf("graphql: cannot decode data: %v", err)
+		}
+	}
+	if len(response.Errors) > 0 {
+		return &ResponseError{Errors: response.Errors}
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("graphql: unexpected status %v", res.Status)
+	}
+	return nil
+}
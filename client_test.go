@@ -0,0 +1,70 @@
+package structgraphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	structgraphql "github.com/onichandame/struct-graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient(t *testing.T) {
+	var received struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	var header http.Header
+	respond := func(status int, body string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&received))
+			w.WriteHeader(status)
+			w.Write([]byte(body))
+		}))
+	}
+	t.Run("sends operations and decodes data", func(t *testing.T) {
+		server := respond(http.StatusOK, `{"data":{"todos":[{"title":"a"}]}}`)
+		defer server.Close()
+		client := structgraphql.NewClient(server.URL, structgraphql.WithTransport(server.Client()), structgraphql.WithHeader("Authorization", "Bearer token"))
+		var res struct {
+			Todos []struct{ Title string } `json:"todos"`
+		}
+		err := client.Do(context.Background(), "query Todos($first: Int) { todos { title } }", map[string]interface{}{"first": 1}, &res)
+		assert.Nil(t, err)
+		assert.Equal(t, "a", res.Todos[0].Title)
+		assert.Equal(t, "query Todos($first: Int) { todos { title } }", received.Query)
+		assert.Equal(t, map[string]interface{}{"first": float64(1)}, received.Variables)
+		assert.Equal(t, "Bearer token", header.Get("Authorization"))
+		assert.Equal(t, "application/json", header.Get("Content-Type"))
+	})
+	t.Run("returns the errors along with the data", func(t *testing.T) {
+		server := respond(http.StatusOK, `{"data":{"todos":null,"version":"1"},"errors":[{"message":"first"},{"message":"second","path":["todos"]}]}`)
+		defer server.Close()
+		client := structgraphql.NewClient(server.URL, structgraphql.WithTransport(server.Client()))
+		var res struct {
+			Version string `json:"version"`
+		}
+		err := client.Do(context.Background(), "{ todos { title } version }", nil, &res)
+		var resErr *structgraphql.ResponseError
+		assert.ErrorAs(t, err, &resErr)
+		assert.Equal(t, "graphql: first; second", err.Error())
+		assert.Equal(t, []interface{}{"todos"}, resErr.Errors[1].Path)
+		assert.Equal(t, "1", res.Version)
+	})
+	t.Run("rejects responses that are not graphql", func(t *testing.T) {
+		server := respond(http.StatusBadGateway, `bad gateway`)
+		defer server.Close()
+		client := structgraphql.NewClient(server.URL, structgraphql.WithTransport(server.Client()))
+		err := client.Do(context.Background(), "{ version }", nil, nil)
+		assert.Contains(t, err.Error(), "unexpected status 502")
+
+		server = respond(http.StatusOK, `bad`)
+		defer server.Close()
+		client = structgraphql.NewClient(server.URL, structgraphql.WithTransport(server.Client()))
+		err = client.Do(context.Background(), "{ version }", nil, nil)
+		assert.Contains(t, err.Error(), "cannot decode response")
+	})
+}
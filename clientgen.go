@@ -0,0 +1,558 @@
+package structgraphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/printer"
+	goutils "github.com/onichandame/go-utils"
+)
+
+// write the source of package pkgName, imported as pkgPath, with a function sending each operation of documents to schema with a Client, e.g.
+// func GetUser(ctx context.Context, client *structgraphql.Client, variables GetUserVariables) (*GetUserResult, error).
+// results mirror the selections of the operations. variables reuse the Go types the parser generated their input objects and scalars from
+// when these encode to the JSON the server expects, e.g. structs parsed WithJSONTags, and mirror them otherwise
+func (parser *Parser) GenerateClient(schema graphql.Schema, pkgPath, pkgName string, documents ...string) (res []byte, err error) {
+	defer goutils.RecoverToErr(&err)
+	parser.mu.Lock()
+	defer parser.mu.Unlock()
+	gen := clientGenerator{
+		goGenerator: goGenerator{parser: parser, pkgPath: pkgPath, imports: make(map[string]string)},
+		schema:      schema,
+		fragments:   make(map[string]*ast.FragmentDefinition),
+		declared:    make(map[string]interface{}),
+		reused:      make(map[*graphql.InputObject]*reusedInput),
+	}
+	return gen.generate(pkgName, documents)
+}
+
+type clientGenerator struct {
+	goGenerator
+	schema    graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	// enums and input objects are declared once for all operations
+	declared map[string]interface{}
+	decls    bytes.Buffer
+	reused   map[*graphql.InputObject]*reusedInput
+}
+
+type reusedInput struct {
+	goType reflect.Type
+	ok     bool
+}
+
+func (gen *clientGenerator) generate(pkgName string, documents []string) ([]byte, error) {
+	// fragments can be shared by the operations of all documents
+	doc := ast.NewDocument(nil)
+	for i, document := range documents {
+		parsed, err := parser.Parse(parser.ParseParams{Source: document})
+		if err != nil {
+			return nil, fmt.Errorf("document %v: %v", i, err)
+		}
+		doc.Definitions = append(doc.Definitions, parsed.Definitions...)
+	}
+	if result := graphql.ValidateDocument(&gen.schema, doc, graphql.SpecifiedRules); !result.IsValid {
+		var messages []string
+		for _, e := range result.Errors {
+			messages = append(messages, e.Message)
+		}
+		return nil, fmt.Errorf("invalid operations: %v", strings.Join(messages, "; "))
+	}
+	var operations []*ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.OperationDefinition:
+			operations = append(operations, definition)
+		case *ast.FragmentDefinition:
+			gen.fragments[definition.Name.Value] = definition
+		}
+	}
+	sort.Slice(operations, func(i, j int) bool { return operationName(operations[i]) < operationName(operations[j]) })
+	var body bytes.Buffer
+	for _, operation := range operations {
+		gen.writeOperation(&body, operation)
+	}
+	body.Write(gen.decls.Bytes())
+	return gen.source(pkgName, body.Bytes())
+}
+
+func operationName(operation *ast.OperationDefinition) string {
+	if operation.Name == nil {
+		return ""
+	}
+	return operation.Name.Value
+}
+
+func (gen *clientGenerator) writeOperation(w *bytes.Buffer, operation *ast.OperationDefinition) {
+	name := exportName(operationName(operation))
+	if name == "" {
+		panic(fmt.Errorf("operations must be named to generate their functions"))
+	}
+	var root *graphql.Object
+	switch operation.Operation {
+	case ast.OperationTypeQuery:
+		root = gen.schema.QueryType()
+	case ast.OperationTypeMutation:
+		root = gen.schema.MutationType()
+	default:
+		panic(fmt.Errorf("operation %v: %v cannot be sent over HTTP", name, operation.Operation))
+	}
+	fmt.Fprintf(w, "// %[1]vDocument is sent by %[1]v\nconst %[1]vDocument = %[2]v\n\n", name, quoteDocument(gen.printOperation(operation)))
+	variables := "nil"
+	params := ""
+	if len(operation.VariableDefinitions) > 0 {
+		variables = "variables"
+		params = ", variables " + name + "Variables"
+		fmt.Fprintf(w, "type %vVariables struct {\n", name)
+		for _, definition := range operation.VariableDefinitions {
+			t := gen.typeFromAST(definition.Type)
+			// variables with defaults can be left out like nullable ones
+			optional := definition.DefaultValue != nil
+			fmt.Fprintf(w, "%v %v `json:\"%v%v\"`\n", exportName(definition.Variable.Name.Value), gen.inputType(t, optional), definition.Variable.Name.Value, omitEmpty(t, optional))
+		}
+		w.WriteString("}\n\n")
+	}
+	gen.writeSelection(w, name+"Result", root, []*ast.SelectionSet{operation.SelectionSet})
+	fmt.Fprintf(w, `// send %[1]v with client. the data the server could resolve is returned along with a *structgraphql.ResponseError
+func %[1]v(ctx %[2]v, client *%[3]v%[4]v) (*%[1]vResult, error) {
+	var res %[1]vResult
+	err := client.Do(ctx, %[1]vDocument, %[5]v, &res)
+	return &res, err
+}
+
+`, name, gen.qualify("context", "Context"), gen.qualify(reflect.TypeOf(Client{}).PkgPath(), "Client"), params, variables)
+}
+
+// the operation followed by the fragments it spreads
+func (gen *clientGenerator) printOperation(operation *ast.OperationDefinition) string {
+	used := make(map[string]interface{})
+	var spread func(set *ast.SelectionSet)
+	spread = func(set *ast.SelectionSet) {
+		if set == nil {
+			return
+		}
+		for _, selection := range set.Selections {
+			switch selection := selection.(type) {
+			case *ast.Field:
+				spread(selection.SelectionSet)
+			case *ast.InlineFragment:
+				spread(selection.SelectionSet)
+			case *ast.FragmentSpread:
+				if _, ok := used[selection.Name.Value]; !ok {
+					used[selection.Name.Value] = nil
+					spread(gen.fragments[selection.Name.Value].SelectionSet)
+				}
+			}
+		}
+	}
+	spread(operation.SelectionSet)
+	printed := []string{fmt.Sprint(printer.Print(operation))}
+	for _, name := range sortedKeys(used) {
+		printed = append(printed, fmt.Sprint(printer.Print(gen.fragments[name])))
+	}
+	return strings.Join(printed, "\n\n")
+}
+
+func quoteDocument(document string) string {
+	if strings.Contains(document, "`") {
+		return strconv.Quote(document)
+	}
+	return "`" + document + "`"
+}
+
+func (gen *clientGenerator) typeFromAST(t ast.Type) graphql.Type {
+	switch t := t.(type) {
+	case *ast.NonNull:
+		return graphql.NewNonNull(gen.typeFromAST(t.Type))
+	case *ast.List:
+		return graphql.NewList(gen.typeFromAST(t.Type))
+	case *ast.Named:
+		return gen.schema.Type(t.Name.Value)
+	}
+	return nil
+}
+
+// a field selected by one or more fields of the same response key
+type selectedField struct {
+	key        string
+	definition *graphql.FieldDefinition
+	sets       []*ast.SelectionSet
+	// fields selected on a more specific type or under @include or @skip may be missing from the response
+	optional bool
+}
+
+// declare the struct name mirroring the fields selected on parent by sets
+func (gen *clientGenerator) writeSelection(w *bytes.Buffer, name string, parent graphql.Type, sets []*ast.SelectionSet) {
+	var fields []*selectedField
+	byKey := make(map[string]*selectedField)
+	for _, set := range sets {
+		if set != nil {
+			gen.collectFields(parent, set, false, &fields, byKey)
+		}
+	}
+	var nested bytes.Buffer
+	fmt.Fprintf(w, "type %v struct {\n", name)
+	for _, field := range fields {
+		fieldName := exportName(field.key)
+		goType := gen.outputType(&nested, name+fieldName, field.definition.Type, field.sets)
+		if field.optional && !strings.HasPrefix(goType, "*") && !strings.HasPrefix(goType, "[]") {
+			goType = "*" + goType
+		}
+		fmt.Fprintf(w, "%v %v `json:\"%v\"`\n", fieldName, goType, field.key)
+	}
+	w.WriteString("}\n\n")
+	w.Write(nested.Bytes())
+}
+
+func (gen *clientGenerator) collectFields(parent graphql.Type, set *ast.SelectionSet, optional bool, fields *[]*selectedField, byKey map[string]*selectedField) {
+	for _, selection := range set.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			key := selection.Name.Value
+			if selection.Alias != nil {
+				key = selection.Alias.Value
+			}
+			fieldOptional := optional || isConditional(selection.Directives)
+			if field, ok := byKey[key]; ok {
+				field.sets = append(field.sets, selection.SelectionSet)
+				field.optional = field.optional && fieldOptional
+				continue
+			}
+			field := &selectedField{key: key, definition: fieldDefinition(parent, selection.Name.Value), sets: []*ast.SelectionSet{selection.SelectionSet}, optional: fieldOptional}
+			*fields = append(*fields, field)
+			byKey[key] = field
+		case *ast.InlineFragment:
+			condition := parent
+			if selection.TypeCondition != nil {
+				condition = gen.schema.Type(selection.TypeCondition.Name.Value)
+			}
+			gen.collectFields(condition, selection.SelectionSet, optional || isConditional(selection.Directives) || !covers(condition, parent), fields, byKey)
+		case *ast.FragmentSpread:
+			fragment := gen.fragments[selection.Name.Value]
+			condition := gen.schema.Type(fragment.TypeCondition.Name.Value)
+			gen.collectFields(condition, fragment.SelectionSet, optional || isConditional(selection.Directives) || !covers(condition, parent), fields, byKey)
+		}
+	}
+}
+
+func fieldDefinition(parent graphql.Type, name string) *graphql.FieldDefinition {
+	if name == "__typename" {
+		return &graphql.FieldDefinition{Name: name, Type: graphql.NewNonNull(graphql.String)}
+	}
+	switch parent := parent.(type) {
+	case *graphql.Object:
+		return parent.Fields()[name]
+	case *graphql.Interface:
+		return parent.Fields()[name]
+	}
+	return nil
+}
+
+func isConditional(directives []*ast.Directive) bool {
+	for _, directive := range directives {
+		if directive.Name.Value == graphql.IncludeDirective.Name || directive.Name.Value == graphql.SkipDirective.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// whether every value of type parent is of type condition
+func covers(condition, parent graphql.Type) bool {
+	if condition.Name() == parent.Name() {
+		return true
+	}
+	if object, ok := parent.(*graphql.Object); ok {
+		switch condition := condition.(type) {
+		case *graphql.Interface:
+			for _, iface := range object.Interfaces() {
+				if iface.Name() == condition.Name() {
+					return true
+				}
+			}
+		case *graphql.Union:
+			for _, member := range condition.Types() {
+				if member.Name() == object.Name() {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// the Go type of a field of type t. nested selections are declared in w, named after the path of the field, e.g. GetUserResultUserPosts
+func (gen *clientGenerator) outputType(w *bytes.Buffer, name string, t graphql.Type, sets []*ast.SelectionSet) string {
+	nonNull := false
+	if wrapped, ok := t.(*graphql.NonNull); ok {
+		t = wrapped.OfType
+		nonNull = true
+	}
+	var res string
+	switch t := t.(type) {
+	case *graphql.List:
+		return "[]" + gen.outputType(w, name, t.OfType, sets)
+	case *graphql.Object, *graphql.Interface, *graphql.Union:
+		gen.writeSelection(w, name, t, sets)
+		res = name
+	default:
+		res = gen.leafType(t)
+	}
+	if !nonNull {
+		return "*" + res
+	}
+	return res
+}
+
+// the Go type of an input of type t, a pointer if it is nullable or optional
+func (gen *clientGenerator) inputType(t graphql.Type, optional bool) string {
+	nonNull := false
+	if wrapped, ok := t.(*graphql.NonNull); ok {
+		t = wrapped.OfType
+		nonNull = true
+	}
+	var res string
+	switch t := t.(type) {
+	case *graphql.List:
+		return "[]" + gen.inputType(t.OfType, false)
+	case *graphql.InputObject:
+		res = gen.inputObjectType(t)
+	default:
+		res = gen.leafType(t)
+	}
+	if !nonNull || optional {
+		return "*" + res
+	}
+	return res
+}
+
+// nullable inputs and those with defaults are left out rather than sent as null so that their defaults apply.
+// required ones are always sent, even when empty
+func omitEmpty(t graphql.Type, optional bool) string {
+	if _, nonNull := t.(*graphql.NonNull); nonNull && !optional {
+		return ""
+	}
+	return ",omitempty"
+}
+
+func (gen *clientGenerator) inputObjectType(object *graphql.InputObject) string {
+	if reused := gen.reuseInput(object); reused.ok {
+		return gen.goTypeRef(reused.goType, rootPath(reused.goType))
+	}
+	name := exportName(object.Name())
+	if _, ok := gen.declared[name]; ok {
+		return name
+	}
+	gen.declared[name] = nil
+	var decl bytes.Buffer
+	fields := object.Fields()
+	fmt.Fprintf(&decl, "type %v struct {\n", name)
+	for _, fieldName := range sortedFieldNames(fields) {
+		field := fields[fieldName]
+		writeComment(&decl, field.Description())
+		optional := field.DefaultValue != nil
+		fmt.Fprintf(&decl, "%v %v `json:\"%v%v\"`\n", exportName(fieldName), gen.inputType(field.Type, optional), fieldName, omitEmpty(field.Type, optional))
+	}
+	decl.WriteString("}\n\n")
+	gen.decls.Write(decl.Bytes())
+	return name
+}
+
+// the Go type of a scalar or enum
+func (gen *clientGenerator) leafType(t graphql.Type) string {
+	switch t {
+	case graphql.String, graphql.ID:
+		return "string"
+	case graphql.Int:
+		return "int"
+	case graphql.Float:
+		return "float64"
+	case graphql.Boolean:
+		return "bool"
+	case graphql.DateTime:
+		return gen.qualify("time", "Time")
+	case JSONScalar:
+		return gen.qualify("encoding/json", "RawMessage")
+	case Int64Scalar, BigIntScalar, DecimalScalar, DurationScalar, DateScalar, URLScalar, UUIDScalar, Base64Scalar:
+		// these scalars are serialized as strings
+		return "string"
+	}
+	switch t := t.(type) {
+	case *graphql.Enum:
+		return gen.enumType(t)
+	case *graphql.Scalar:
+		if base, ok := gen.parser.scalarBases[t]; ok {
+			if named, ok := gen.parser.names[t.Name()]; ok && named.gqlType == t && gen.isReusable(named.goType) && encodesAsScalar(named.goType, base) {
+				return gen.goTypeRef(named.goType, rootPath(named.goType))
+			}
+			return gen.leafType(base)
+		}
+	}
+	// the serialization of other scalars is unknown
+	return gen.qualify("encoding/json", "RawMessage")
+}
+
+// enums are received by name, so the values of Go enums cannot be reused
+func (gen *clientGenerator) enumType(enum *graphql.Enum) string {
+	name := exportName(enum.Name())
+	if _, ok := gen.declared[name]; ok {
+		return name
+	}
+	gen.declared[name] = nil
+	writeComment(&gen.decls, enum.Description())
+	fmt.Fprintf(&gen.decls, "type %v string\n\nconst (\n", name)
+	values := append([]*graphql.EnumValueDefinition{}, enum.Values()...)
+	sort.Slice(values, func(i, j int) bool { return values[i].Name < values[j].Name })
+	for _, value := range values {
+		writeComment(&gen.decls, value.Description)
+		fmt.Fprintf(&gen.decls, "%v%v %v = %q\n", name, exportName(value.Name), name, value.Name)
+	}
+	gen.decls.WriteString(")\n\n")
+	return name
+}
+
+// whether the generated package can refer to Go type t
+func (gen *clientGenerator) isReusable(t reflect.Type) bool {
+	return t.Name() != "" && t.PkgPath() != "" && t.PkgPath() != "main" && !strings.HasSuffix(t.PkgPath(), "_test") &&
+		(token.IsExported(t.Name()) || t.PkgPath() == gen.pkgPath)
+}
+
+// the struct an input object was parsed from is reused when its JSON fields are those of the input object, with values encoding to their types
+func (gen *clientGenerator) reuseInput(object *graphql.InputObject) *reusedInput {
+	if reused, ok := gen.reused[object]; ok {
+		return reused
+	}
+	named, ok := gen.parser.names[object.Name()]
+	if !ok || named.gqlType != object || named.goType.Kind() != reflect.Struct || !gen.isReusable(named.goType) {
+		gen.reused[object] = &reusedInput{}
+		return gen.reused[object]
+	}
+	// recursive inputs are assumed reusable while their fields are checked
+	reused := &reusedInput{goType: named.goType, ok: true}
+	gen.reused[object] = reused
+	fields := object.Fields()
+	jsonFields := getJSONFields(named.goType)
+	reused.ok = len(fields) == len(jsonFields)
+	for name, field := range jsonFields {
+		inputField, ok := fields[name]
+		if !ok || !gen.encodesAs(field.Type, inputField.Type) {
+			reused.ok = false
+			continue
+		}
+		// the zero values of required fields must still be sent
+		if _, nonNull := inputField.Type.(*graphql.NonNull); nonNull && hasJSONOption(&field, "omitempty") {
+			reused.ok = false
+		}
+	}
+	return reused
+}
+
+// whether values of Go type t encode to JSON as values of input type input
+func (gen *clientGenerator) encodesAs(t reflect.Type, input graphql.Type) bool {
+	if wrapped, ok := input.(*graphql.NonNull); ok {
+		// values that can be nil would be sent as null
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+			return false
+		}
+		input = wrapped.OfType
+	}
+	t = getType(t)
+	switch input := input.(type) {
+	case *graphql.List:
+		return t.Kind() == reflect.Slice && !isBytes(t) && gen.encodesAs(t.Elem(), input.OfType)
+	case *graphql.InputObject:
+		reused := gen.reuseInput(input)
+		return reused.ok && reused.goType == t
+	case *graphql.Scalar:
+		if base, ok := gen.parser.scalarBases[input]; ok {
+			named, ok := gen.parser.names[input.Name()]
+			return ok && named.goType == t && encodesAsScalar(t, base)
+		}
+		return encodesAsScalar(t, input)
+	}
+	return false
+}
+
+// whether values of Go type t encode to JSON as values of a builtin scalar
+func encodesAsScalar(t reflect.Type, scalar graphql.Type) bool {
+	switch scalar {
+	case graphql.String:
+		return t.Kind() == reflect.String
+	case graphql.Boolean:
+		return t.Kind() == reflect.Bool
+	case graphql.Int:
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+			return true
+		}
+	case graphql.Float:
+		return isNumberKind(t.Kind())
+	case graphql.DateTime:
+		return t == reflect.TypeOf(time.Time{})
+	case JSONScalar:
+		return t == reflect.TypeOf(json.RawMessage{})
+	}
+	return false
+}
+
+// the fields encoding/json encodes struct t with, by name
+func getJSONFields(t reflect.Type) map[string]reflect.StructField {
+	res := make(map[string]reflect.StructField)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" && getType(field.Type).Kind() == reflect.Struct {
+			for name, promoted := range getJSONFields(getType(field.Type)) {
+				// the fields of a nil embedded pointer are left out like nil pointers
+				if field.Type.Kind() == reflect.Ptr {
+					promoted.Type = reflect.PtrTo(promoted.Type)
+				}
+				res[name] = promoted
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		res[name] = field
+	}
+	return res
+}
+
+func hasJSONOption(field *reflect.StructField, option string) bool {
+	for _, opt := range strings.Split(field.Tag.Get("json"), ",")[1:] {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// a Go identifier exported from a graphql name, e.g. Typename for __typename
+func exportName(name string) string {
+	return upperFirst(strings.TrimLeft(name, "_"))
+}
+
+func writeComment(w *bytes.Buffer, text string) {
+	if text != "" {
+		w.WriteString("// " + strings.ReplaceAll(text, "\n", "\n// ") + "\n")
+	}
+}
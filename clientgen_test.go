@@ -0,0 +1,109 @@
+package structgraphql_test
+
+import (
+	"context"
+	"testing"
+
+	structgraphql "github.com/onichandame/struct-graphql"
+	"github.com/onichandame/struct-graphql/internal/testclient/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateClient(t *testing.T) {
+	parser := structgraphql.NewParser()
+	resolver := new(TodoResolver)
+	schema, err := structgraphql.NewSchemaBuilder(parser).
+		Query("todos", resolver.Todos).
+		Mutation("addTodo", resolver.AddTodo).
+		Subscription("todoAdded", func(ctx context.Context) <-chan *Todo { return nil }).
+		Build()
+	assert.Nil(t, err)
+	t.Run("writes a function per operation", func(t *testing.T) {
+		src, err := parser.GenerateClient(schema, "example.com/client", "client",
+			`query ListTodos { todos { ...TodoFields } }`,
+			`mutation AddTodo($title: String!) { todo: addTodo(title: $title) { id } }
+			fragment TodoFields on Todo { id title }`,
+		)
+		assert.Nil(t, err)
+		assert.Contains(t, string(src), "package client\n")
+		assert.Contains(t, string(src), "func ListTodos(ctx context.Context, client *structgraphql.Client) (*ListTodosResult, error) {\n")
+		assert.Contains(t, string(src), "err := client.Do(ctx, ListTodosDocument, nil, &res)\n")
+		assert.Contains(t, string(src), "fragment TodoFields on Todo {\n")
+		assert.Contains(t, string(src), "Todos []*ListTodosResultTodos `json:\"todos\"`\n")
+		assert.Contains(t, string(src), "func AddTodo(ctx context.Context, client *structgraphql.Client, variables AddTodoVariables) (*AddTodoResult, error) {\n")
+		assert.Contains(t, string(src), "Title string `json:\"title\"`\n")
+		assert.Contains(t, string(src), "Todo *AddTodoResultTodo `json:\"todo\"`\n")
+		assert.NotContains(t, string(src), "example.com/client\"")
+	})
+	t.Run("mirrors types of other packages that do not encode as the schema expects", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		resolver := new(TodoResolver)
+		schema, err := structgraphql.NewSchemaBuilder(parser).
+			Query("todos", resolver.Todos).
+			Mutation("addTodo", func(ctx context.Context, args struct {
+				Todo TodoArgs `graphql:"todo"`
+			}) (*Todo, error) {
+				return resolver.AddTodo(ctx, args.Todo)
+			}).
+			Build()
+		assert.Nil(t, err)
+		src, err := parser.GenerateClient(schema, "example.com/client", "client", `mutation AddTodo($todo: TodoArgs!) { addTodo(todo: $todo) { id } }`)
+		assert.Nil(t, err)
+		assert.Contains(t, string(src), "Todo TodoArgs `json:\"todo\"`\n")
+		assert.Contains(t, string(src), "type TodoArgs struct {\n")
+	})
+	t.Run("reuses only structs whose zero values are valid", func(t *testing.T) {
+		parser := models.NewParser()
+		schema, err := structgraphql.NewSchemaBuilder(parser).
+			Query("search", func(args struct {
+				Search models.TicketSearch `graphql:"search"`
+				Filter models.TicketFilter `graphql:"filter"`
+			}) bool {
+				return true
+			}).
+			Build()
+		assert.Nil(t, err)
+		src, err := parser.GenerateClient(schema, "example.com/client", "client", `query Search($search: TicketSearch!, $filter: TicketFilter!) { search(search: $search, filter: $filter) }`)
+		assert.Nil(t, err)
+		assert.Regexp(t, "Search +TicketSearch +`json:\"search\"`", string(src))
+		assert.Regexp(t, "Filter +models.TicketFilter +`json:\"filter\"`", string(src))
+		assert.Regexp(t, "Ids +\\[\\]\\*int +`json:\"ids\"`", string(src))
+		assert.Regexp(t, "Title +string +`json:\"title\"`", string(src))
+	})
+	t.Run("omits only optional variables", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		type Filter struct {
+			IDs  []int `graphql:"ids,nonnullitems"`
+			Tags []int `graphql:"tags,nullable"`
+		}
+		schema, err := structgraphql.NewSchemaBuilder(parser).
+			Query("count", func(args struct {
+				IDs    []int  `graphql:"ids,nonnullitems"`
+				More   []int  `graphql:"more,nullable,nonnullitems"`
+				Filter Filter `graphql:"filter"`
+			}) int {
+				return len(args.IDs)
+			}).
+			Build()
+		assert.Nil(t, err)
+		src, err := parser.GenerateClient(schema, "example.com/client", "client", `query Count($ids: [Int!]!, $more: [Int!], $filter: Filter!) { count(ids: $ids, more: $more, filter: $filter) }`)
+		assert.Nil(t, err)
+		assert.Regexp(t, "Ids +\\[\\]int +`json:\"ids\"`", string(src))
+		assert.Regexp(t, "More +\\[\\]int +`json:\"more,omitempty\"`", string(src))
+		assert.Regexp(t, "Filter +Filter +`json:\"filter\"`", string(src))
+		assert.Regexp(t, "Tags +\\[\\]\\*int +`json:\"tags,omitempty\"`", string(src))
+	})
+	t.Run("rejects operations it cannot send", func(t *testing.T) {
+		_, err := parser.GenerateClient(schema, "example.com/client", "client", `{ todos { id } }`)
+		assert.Contains(t, err.Error(), "operations must be named")
+
+		_, err = parser.GenerateClient(schema, "example.com/client", "client", `subscription TodoAdded { todoAdded { id } }`)
+		assert.Contains(t, err.Error(), "cannot be sent over HTTP")
+
+		_, err = parser.GenerateClient(schema, "example.com/client", "client", `query Missing { missing }`)
+		assert.Contains(t, err.Error(), "invalid operations")
+
+		_, err = parser.GenerateClient(schema, "example.com/client", "client", `query {`)
+		assert.Contains(t, err.Error(), "document 0")
+	})
+}
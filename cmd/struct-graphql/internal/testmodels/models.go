@@ -0,0 +1,110 @@
+// Package testmodels declares the types struct-graphql is tested against. models_graphql.go is generated from them
+package testmodels
+
+import (
+	"time"
+
+	structgraphql "github.com/onichandame/struct-graphql"
+)
+
+//go:generate go run ../.. -type Library,Catalog -input BookFilter -parser NewParser -output models_graphql.go
+
+type BookID int
+
+func (BookID) IsID() bool { return true }
+
+type ISBN string
+
+func (ISBN) GetDescription() string { return "International Standard Book Number" }
+
+type Genre int
+
+const (
+	GenreFiction Genre = iota
+	GenreScience
+	GenrePoetry
+)
+
+func (Genre) GetValues() map[string]interface{} {
+	return map[string]interface{}{"Fiction": GenreFiction, "Science": GenreScience, "Poetry": GenrePoetry}
+}
+func (Genre) GetValueDescriptions() map[string]string {
+	return map[string]string{"Science": "non-fiction about science"}
+}
+func (Genre) GetValueDeprecations() map[string]string {
+	return map[string]string{"Poetry": "use Fiction"}
+}
+
+type Node interface{ GetID() BookID }
+
+type Item interface{ isItem() }
+
+type Entity struct {
+	ID BookID `graphql:"id"`
+}
+
+func (e *Entity) GetID() BookID { return e.ID }
+
+type Audit struct {
+	Created time.Time `graphql:"created,nullable"`
+}
+
+type Book struct {
+	Entity
+	*Audit
+	Title   string        `graphql:"title"`
+	ISBN    ISBN          `graphql:"isbn,nullable"`
+	Genre   Genre         `graphql:"genre"`
+	Tags    [][]string    `graphql:"tags,nonnullitems"`
+	Length  time.Duration `graphql:"length"`
+	Rating  float64       `gqldesc:"average of the reviews"`
+	Blurb   string        `graphql:"blurb,nullable" gqldeprecated:"use summary"`
+	Summary string        `graphql:"summary"`
+	secret  string
+}
+
+func (*Book) isItem() {}
+
+func (Book) GetDescription() string { return "A book.\nBooks are sorted by title." }
+
+type Magazine struct {
+	Entity
+	Issue int `graphql:"issue"`
+}
+
+func (*Magazine) isItem() {}
+
+type Library struct {
+	Name  string  `graphql:"name"`
+	Books []*Book `graphql:"books"`
+	Items []Item  `graphql:"items"`
+}
+
+func (Library) GetName() string { return "Shelves" }
+
+type Catalog struct {
+	Magazines []Magazine `graphql:"magazines"`
+}
+
+type BookFilter struct {
+	Title  string   `graphql:"title,nullable" gqldesc:"Prefix of the titles"`
+	Genres []Genre  `graphql:"genres,nullable,default=[Fiction,Science]"`
+	Limit  int      `graphql:"limit,nullable,default=10"`
+	Ratio  float64  `graphql:"ratio,nullable,default=0.5"`
+	Tags   []string `graphql:"tags,nullable,default=[a]"`
+	Order  Order    `graphql:"order,nullable"`
+}
+
+type Order struct {
+	Field      string `graphql:"field"`
+	Descending bool   `graphql:"descending,nullable,default=true"`
+}
+
+func (Order) GetInputName() string { return "OrderInput" }
+
+func NewParser() *structgraphql.Parser {
+	parser := structgraphql.NewParser(structgraphql.WithScalars())
+	parser.AddInterface((*Node)(nil), Entity{})
+	parser.AddUnion((*Item)(nil), Book{}, Magazine{})
+	return parser
+}
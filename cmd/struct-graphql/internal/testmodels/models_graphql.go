@@ -0,0 +1,372 @@
+// Code generated by struct-graphql. DO NOT EDIT.
+
+package testmodels
+
+import (
+	"github.com/graphql-go/graphql"
+	structgraphql "github.com/onichandame/struct-graphql"
+)
+
+var (
+	GraphQLBookID     *graphql.Scalar
+	GraphQLISBN       *graphql.Scalar
+	GraphQLGenre      *graphql.Enum
+	GraphQLNode       *graphql.Interface
+	GraphQLBook       *graphql.Object
+	GraphQLCatalog    *graphql.Object
+	GraphQLMagazine   *graphql.Object
+	GraphQLShelves    *graphql.Object
+	GraphQLItem       *graphql.Union
+	GraphQLBookFilter *graphql.InputObject
+	GraphQLOrderInput *graphql.InputObject
+)
+
+// every type declared in this file, e.g. for graphql.SchemaConfig.Types
+var GraphQLTypes []graphql.Type
+
+func init() {
+	GraphQLBookID = graphql.NewScalar(graphql.ScalarConfig{
+		Name:         "BookID",
+		Serialize:    graphql.ID.Serialize,
+		ParseValue:   graphql.ID.ParseValue,
+		ParseLiteral: graphql.ID.ParseLiteral,
+	})
+	GraphQLISBN = graphql.NewScalar(graphql.ScalarConfig{
+		Name:         "ISBN",
+		Description:  "International Standard Book Number",
+		Serialize:    graphql.String.Serialize,
+		ParseValue:   graphql.String.ParseValue,
+		ParseLiteral: graphql.String.ParseLiteral,
+	})
+	GraphQLGenre = graphql.NewEnum(graphql.EnumConfig{
+		Name: "Genre",
+		Values: graphql.EnumValueConfigMap{
+			"Fiction": &graphql.EnumValueConfig{
+				Value: Genre(0),
+			},
+			"Poetry": &graphql.EnumValueConfig{
+				Value:             Genre(2),
+				DeprecationReason: "use Fiction",
+			},
+			"Science": &graphql.EnumValueConfig{
+				Value:       Genre(1),
+				Description: "non-fiction about science",
+			},
+		},
+	})
+	GraphQLNode = graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id": &graphql.Field{
+					Type: graphql.NewNonNull(GraphQLBookID),
+				},
+			}
+		}),
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			switch p.Value.(type) {
+			case Book, *Book:
+				return GraphQLBook
+			case Magazine, *Magazine:
+				return GraphQLMagazine
+			}
+			return nil
+		},
+	})
+	GraphQLBook = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Book",
+		Description: "A book.\nBooks are sorted by title.",
+		Interfaces:  []*graphql.Interface{GraphQLNode},
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"Rating": &graphql.Field{
+					Type:        graphql.Float,
+					Description: "average of the reviews",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source, ok := graphQLBookSource(p.Source)
+						if !ok {
+							return graphql.DefaultResolveFn(p)
+						}
+						return source.Rating, nil
+					},
+				},
+				"blurb": &graphql.Field{
+					Type:              graphql.String,
+					DeprecationReason: "use summary",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source, ok := graphQLBookSource(p.Source)
+						if !ok {
+							return graphql.DefaultResolveFn(p)
+						}
+						return source.Blurb, nil
+					},
+				},
+				"created": &graphql.Field{
+					Type: graphql.DateTime,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source, ok := graphQLBookSource(p.Source)
+						if !ok {
+							return graphql.DefaultResolveFn(p)
+						}
+						if source.Audit == nil {
+							return nil, nil
+						}
+						return source.Audit.Created, nil
+					},
+				},
+				"genre": &graphql.Field{
+					Type: graphql.NewNonNull(GraphQLGenre),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source, ok := graphQLBookSource(p.Source)
+						if !ok {
+							return graphql.DefaultResolveFn(p)
+						}
+						return source.Genre, nil
+					},
+				},
+				"id": &graphql.Field{
+					Type: graphql.NewNonNull(GraphQLBookID),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source, ok := graphQLBookSource(p.Source)
+						if !ok {
+							return graphql.DefaultResolveFn(p)
+						}
+						return source.Entity.ID, nil
+					},
+				},
+				"isbn": &graphql.Field{
+					Type:        GraphQLISBN,
+					Description: "International Standard Book Number",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source, ok := graphQLBookSource(p.Source)
+						if !ok {
+							return graphql.DefaultResolveFn(p)
+						}
+						return source.ISBN, nil
+					},
+				},
+				"length": &graphql.Field{
+					Type: graphql.NewNonNull(structgraphql.DurationScalar),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source, ok := graphQLBookSource(p.Source)
+						if !ok {
+							return graphql.DefaultResolveFn(p)
+						}
+						return source.Length, nil
+					},
+				},
+				"summary": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.String),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source, ok := graphQLBookSource(p.Source)
+						if !ok {
+							return graphql.DefaultResolveFn(p)
+						}
+						return source.Summary, nil
+					},
+				},
+				"tags": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))))),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source, ok := graphQLBookSource(p.Source)
+						if !ok {
+							return graphql.DefaultResolveFn(p)
+						}
+						return source.Tags, nil
+					},
+				},
+				"title": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.String),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source, ok := graphQLBookSource(p.Source)
+						if !ok {
+							return graphql.DefaultResolveFn(p)
+						}
+						return source.Title, nil
+					},
+				},
+			}
+		}),
+	})
+	GraphQLCatalog = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Catalog",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"magazines": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(GraphQLMagazine)),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source, ok := graphQLCatalogSource(p.Source)
+						if !ok {
+							return graphql.DefaultResolveFn(p)
+						}
+						return source.Magazines, nil
+					},
+				},
+			}
+		}),
+	})
+	GraphQLMagazine = graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Magazine",
+		Interfaces: []*graphql.Interface{GraphQLNode},
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id": &graphql.Field{
+					Type: graphql.NewNonNull(GraphQLBookID),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source, ok := graphQLMagazineSource(p.Source)
+						if !ok {
+							return graphql.DefaultResolveFn(p)
+						}
+						return source.Entity.ID, nil
+					},
+				},
+				"issue": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.Int),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source, ok := graphQLMagazineSource(p.Source)
+						if !ok {
+							return graphql.DefaultResolveFn(p)
+						}
+						return source.Issue, nil
+					},
+				},
+			}
+		}),
+	})
+	GraphQLShelves = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Shelves",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"books": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.NewList(GraphQLBook)),
+					Description: "A book.\nBooks are sorted by title.",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source, ok := graphQLShelvesSource(p.Source)
+						if !ok {
+							return graphql.DefaultResolveFn(p)
+						}
+						return source.Books, nil
+					},
+				},
+				"items": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(GraphQLItem)),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source, ok := graphQLShelvesSource(p.Source)
+						if !ok {
+							return graphql.DefaultResolveFn(p)
+						}
+						return source.Items, nil
+					},
+				},
+				"name": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.String),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source, ok := graphQLShelvesSource(p.Source)
+						if !ok {
+							return graphql.DefaultResolveFn(p)
+						}
+						return source.Name, nil
+					},
+				},
+			}
+		}),
+	})
+	GraphQLItem = graphql.NewUnion(graphql.UnionConfig{
+		Name:  "Item",
+		Types: []*graphql.Object{GraphQLBook, GraphQLMagazine},
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			switch p.Value.(type) {
+			case Book, *Book:
+				return GraphQLBook
+			case Magazine, *Magazine:
+				return GraphQLMagazine
+			}
+			return nil
+		},
+	})
+	GraphQLBookFilter = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "BookFilter",
+		Fields: graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap {
+			return graphql.InputObjectConfigFieldMap{
+				"genres": &graphql.InputObjectFieldConfig{
+					Type:         graphql.NewList(GraphQLGenre),
+					DefaultValue: []interface{}{Genre(0), Genre(1)},
+				},
+				"limit": &graphql.InputObjectFieldConfig{
+					Type:         graphql.Int,
+					DefaultValue: 10,
+				},
+				"order": &graphql.InputObjectFieldConfig{
+					Type: GraphQLOrderInput,
+				},
+				"ratio": &graphql.InputObjectFieldConfig{
+					Type:         graphql.Float,
+					DefaultValue: float64(0.5),
+				},
+				"tags": &graphql.InputObjectFieldConfig{
+					Type:         graphql.NewList(graphql.String),
+					DefaultValue: []interface{}{"a"},
+				},
+				"title": &graphql.InputObjectFieldConfig{
+					Type:        graphql.String,
+					Description: "Prefix of the titles",
+				},
+			}
+		}),
+	})
+	GraphQLOrderInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "OrderInput",
+		Fields: graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap {
+			return graphql.InputObjectConfigFieldMap{
+				"descending": &graphql.InputObjectFieldConfig{
+					Type:         graphql.Boolean,
+					DefaultValue: true,
+				},
+				"field": &graphql.InputObjectFieldConfig{
+					Type: graphql.NewNonNull(graphql.String),
+				},
+			}
+		}),
+	})
+	GraphQLTypes = []graphql.Type{GraphQLBookID, GraphQLISBN, GraphQLGenre, GraphQLNode, GraphQLBook, GraphQLCatalog, GraphQLMagazine, GraphQLShelves, GraphQLItem, GraphQLBookFilter, GraphQLOrderInput}
+}
+
+func graphQLBookSource(source interface{}) (*Book, bool) {
+	switch source := source.(type) {
+	case *Book:
+		return source, source != nil
+	case Book:
+		return &source, true
+	}
+	return nil, false
+}
+
+func graphQLCatalogSource(source interface{}) (*Catalog, bool) {
+	switch source := source.(type) {
+	case *Catalog:
+		return source, source != nil
+	case Catalog:
+		return &source, true
+	}
+	return nil, false
+}
+
+func graphQLMagazineSource(source interface{}) (*Magazine, bool) {
+	switch source := source.(type) {
+	case *Magazine:
+		return source, source != nil
+	case Magazine:
+		return &source, true
+	}
+	return nil, false
+}
+
+func graphQLShelvesSource(source interface{}) (*Library, bool) {
+	switch source := source.(type) {
+	case *Library:
+		return source, source != nil
+	case Library:
+		return &source, true
+	}
+	return nil, false
+}
@@ -0,0 +1,86 @@
+package testmodels_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	structgraphql "github.com/onichandame/struct-graphql"
+	"github.com/onichandame/struct-graphql/cmd/struct-graphql/internal/testmodels"
+	"github.com/stretchr/testify/assert"
+)
+
+var library = &testmodels.Library{
+	Name: "City",
+	Books: []*testmodels.Book{
+		{Entity: testmodels.Entity{ID: 1}, Audit: &testmodels.Audit{Created: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)}, Title: "Dune", ISBN: "978-0441013593", Genre: testmodels.GenreScience, Tags: [][]string{{"sand"}}, Length: time.Hour, Rating: 4.5},
+		{Entity: testmodels.Entity{ID: 2}, Title: "Odes", Genre: testmodels.GenrePoetry},
+	},
+	Items: []testmodels.Item{&testmodels.Book{Entity: testmodels.Entity{ID: 3}, Title: "Emma"}, &testmodels.Magazine{Entity: testmodels.Entity{ID: 4}, Issue: 7}},
+}
+
+// the same query root over either the parsed or the generated types
+func newSchema(t *testing.T, shelves, catalog graphql.Output, filter graphql.Input, types []graphql.Type) graphql.Schema {
+	query := graphql.NewObject(graphql.ObjectConfig{Name: "Query", Fields: graphql.Fields{
+		"library": &graphql.Field{
+			Type: shelves,
+			Args: graphql.FieldConfigArgument{"filter": &graphql.ArgumentConfig{Type: filter}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				filter := p.Args["filter"].(map[string]interface{})
+				res := *library
+				res.Name = filter["order"].(map[string]interface{})["field"].(string)
+				return &res, nil
+			},
+		},
+		"catalog": &graphql.Field{
+			Type: catalog,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return testmodels.Catalog{Magazines: []testmodels.Magazine{{Entity: testmodels.Entity{ID: 5}, Issue: 1}}}, nil
+			},
+		},
+	}})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: query, Types: types})
+	assert.Nil(t, err)
+	return schema
+}
+
+func TestGenerated(t *testing.T) {
+	parser := testmodels.NewParser()
+	shelves := parser.ParseOutput(testmodels.Library{})
+	catalog := parser.ParseOutput(testmodels.Catalog{})
+	filter := parser.ParseInput(testmodels.BookFilter{})
+	parsed := newSchema(t, shelves, catalog, filter, nil)
+	generated := newSchema(t, testmodels.GraphQLShelves, testmodels.GraphQLCatalog, testmodels.GraphQLBookFilter, testmodels.GraphQLTypes)
+	t.Run("is up to date", func(t *testing.T) {
+		src, err := parser.GenerateGo("github.com/onichandame/struct-graphql/cmd/struct-graphql/internal/testmodels", "testmodels")
+		assert.Nil(t, err)
+		committed, err := os.ReadFile("models_graphql.go")
+		assert.Nil(t, err)
+		assert.Equal(t, string(committed), string(src))
+	})
+	t.Run("declares the parsed types", func(t *testing.T) {
+		sdl := structgraphql.PrintSchema(parsed)
+		assert.Contains(t, sdl, "union Item = Book | Magazine")
+		assert.Equal(t, sdl, structgraphql.PrintSchema(generated))
+	})
+	t.Run("resolves like the parsed types", func(t *testing.T) {
+		query := `{
+			library(filter: {order: {field: "title"}}) {
+				name
+				books { id created isbn genre tags length Rating blurb summary title }
+				items { __typename ... on Node { id } ... on Book { title } ... on Magazine { issue } }
+			}
+			catalog { magazines { id issue } }
+		}`
+		expected := graphql.Do(graphql.Params{Schema: parsed, RequestString: query})
+		assert.Nil(t, expected.Errors)
+		actual := graphql.Do(graphql.Params{Schema: generated, RequestString: query})
+		assert.Nil(t, actual.Errors)
+		assert.Equal(t, expected.Data, actual.Data)
+		data := actual.Data.(map[string]interface{})["library"].(map[string]interface{})
+		assert.Equal(t, "title", data["name"])
+		assert.Equal(t, map[string]interface{}{"__typename": "Magazine", "id": "4", "issue": 7}, data["items"].([]interface{})[1])
+		assert.Nil(t, data["books"].([]interface{})[1].(map[string]interface{})["created"])
+	})
+}
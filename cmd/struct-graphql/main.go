@@ -0,0 +1,230 @@
+// struct-graphql writes Go source declaring the graphql-go types parsed from the structs of a package, so that they are
+// checked by the compiler and need no reflection at startup. it is meant to be run by go generate, e.g.
+//
+//	//go:generate go run github.com/onichandame/struct-graphql/cmd/struct-graphql -type Library,Search -input BookFilter
+//
+// the types are parsed by a structgraphql.NewParser() unless -parser names a func() *structgraphql.Parser of the package,
+// which can configure naming and nullability and register interfaces and unions
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const structgraphqlPath = "github.com/onichandame/struct-graphql"
+
+type config struct {
+	dir     string
+	outputs []string
+	inputs  []string
+	parser  string
+	output  string
+}
+
+func main() {
+	var conf config
+	var outputs, inputs string
+	flag.StringVar(&outputs, "type", "", "comma-separated list of the types to parse as outputs")
+	flag.StringVar(&inputs, "input", "", "comma-separated list of the types to parse as inputs")
+	flag.StringVar(&conf.parser, "parser", "", "name of a func() *structgraphql.Parser of the package creating the parser")
+	flag.StringVar(&conf.output, "output", "graphql_gen.go", "file to write, relative to the package directory")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: struct-graphql [flags] [directory]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	conf.dir = "."
+	if flag.NArg() > 0 {
+		conf.dir = flag.Arg(0)
+	}
+	conf.outputs = splitNames(outputs)
+	conf.inputs = splitNames(inputs)
+	if err := generate(&conf); err != nil {
+		fmt.Fprintln(os.Stderr, "struct-graphql:", err)
+		os.Exit(1)
+	}
+}
+
+func splitNames(list string) []string {
+	var res []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			res = append(res, name)
+		}
+	}
+	return res
+}
+
+// the types are parsed by a program importing the package, as struct tags and the methods of the types are only known at run time
+func generate(conf *config) error {
+	if len(conf.outputs) == 0 && len(conf.inputs) == 0 {
+		return errors.New("no types given by -type or -input")
+	}
+	dir, err := filepath.Abs(conf.dir)
+	if err != nil {
+		return err
+	}
+	output := conf.output
+	if !filepath.IsAbs(output) {
+		output = filepath.Join(dir, output)
+	}
+	pkg, err := loadPackage(dir)
+	if err != nil {
+		return err
+	}
+	if err := checkPackage(pkg, conf); err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp(dir, ".struct-graphql")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	var program bytes.Buffer
+	if err := programTemplate.Execute(&program, map[string]interface{}{
+		"StructGraphQL": structgraphqlPath,
+		"Package":       pkg,
+		"Parser":        conf.parser,
+		"Outputs":       conf.outputs,
+		"Inputs":        conf.inputs,
+		"Output":        output,
+	}); err != nil {
+		return err
+	}
+	programFile := filepath.Join(tmp, "main.go")
+	if err := os.WriteFile(programFile, program.Bytes(), 0644); err != nil {
+		return err
+	}
+	stderr, err := run(dir, programFile)
+	if _, statErr := os.Stat(output); err != nil && statErr == nil {
+		// a previously generated file may no longer compile once the types have changed, so it is replaced by its package clause
+		overlay := filepath.Join(tmp, "overlay.json")
+		replaced := filepath.Join(tmp, "replaced.go")
+		replace, _ := json.Marshal(map[string]interface{}{"Replace": map[string]string{output: replaced}})
+		if err := os.WriteFile(replaced, []byte("package "+pkg.Name+"\n"), 0644); err != nil {
+			return err
+		}
+		if err := os.WriteFile(overlay, replace, 0644); err != nil {
+			return err
+		}
+		stderr, err = run(dir, "-overlay", overlay, programFile)
+	}
+	if err != nil {
+		os.Stderr.Write(stderr)
+	}
+	return err
+}
+
+// run the generating program in the module of the package
+func run(dir string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("go", append([]string{"run"}, args...)...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	return stderr.Bytes(), err
+}
+
+// the package is only parsed, as its generated file may not compile
+func loadPackage(dir string) (*packages.Package, error) {
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax, Dir: dir}, ".")
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected a single package in %v but found %v", dir, len(pkgs))
+	}
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return nil, pkg.Errors[0]
+	}
+	if pkg.Name == "main" {
+		return nil, errors.New("types of main packages cannot be imported by the generator")
+	}
+	return pkg, nil
+}
+
+// report missing types and parsers before running the generator, whose compilation errors are harder to read
+func checkPackage(pkg *packages.Package, conf *config) error {
+	typeNames := make(map[string]interface{})
+	funcs := make(map[string]*ast.FuncDecl)
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					if spec, ok := spec.(*ast.TypeSpec); ok {
+						typeNames[spec.Name.Name] = nil
+					}
+				}
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					funcs[decl.Name.Name] = decl
+				}
+			}
+		}
+	}
+	for _, name := range append(append([]string{}, conf.outputs...), conf.inputs...) {
+		if _, ok := typeNames[name]; !ok || !token.IsExported(name) {
+			return fmt.Errorf("%v is not an exported type of %v", name, pkg.PkgPath)
+		}
+	}
+	if conf.parser != "" {
+		fn, ok := funcs[conf.parser]
+		if !ok || !token.IsExported(conf.parser) {
+			return fmt.Errorf("%v is not an exported function of %v", conf.parser, pkg.PkgPath)
+		}
+		if fn.Type.Params.NumFields() != 0 || fn.Type.Results.NumFields() != 1 {
+			return fmt.Errorf("%v must be a func() *structgraphql.Parser", conf.parser)
+		}
+	}
+	return nil
+}
+
+var programTemplate = template.Must(template.New("main").Parse(`package main
+
+import (
+	"fmt"
+	"os"
+
+	structgraphql {{printf "%q" .StructGraphQL}}
+	target {{printf "%q" .Package.PkgPath}}
+)
+
+func main() {
+	var parser *structgraphql.Parser
+	{{if .Parser}}parser = target.{{.Parser}}(){{else}}parser = structgraphql.NewParser(){{end}}
+	{{range .Outputs}}if _, err := parser.TryParseOutput(new(target.{{.}})); err != nil {
+		fail(err)
+	}
+	{{end}}{{range .Inputs}}if _, err := parser.TryParseInput(new(target.{{.}})); err != nil {
+		fail(err)
+	}
+	{{end}}src, err := parser.GenerateGo({{printf "%q" .Package.PkgPath}}, {{printf "%q" .Package.Name}})
+	if err != nil {
+		fail(err)
+	}
+	if err := os.WriteFile({{printf "%q" .Output}}, src, 0644); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+`))
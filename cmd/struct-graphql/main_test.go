@@ -0,0 +1,26 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("writes the generated types", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "models_graphql.go")
+		assert.Nil(t, generate(&config{dir: "internal/testmodels", outputs: []string{"Library", "Catalog"}, inputs: []string{"BookFilter"}, parser: "NewParser", output: output}))
+		generated, err := os.ReadFile(output)
+		assert.Nil(t, err)
+		committed, err := os.ReadFile("internal/testmodels/models_graphql.go")
+		assert.Nil(t, err)
+		assert.Equal(t, string(committed), string(generated))
+	})
+	t.Run("reports unknown types and parsers", func(t *testing.T) {
+		assert.EqualError(t, generate(&config{dir: "internal/testmodels", outputs: []string{"Shelf"}}), "Shelf is not an exported type of github.com/onichandame/struct-graphql/cmd/struct-graphql/internal/testmodels")
+		assert.EqualError(t, generate(&config{dir: "internal/testmodels", outputs: []string{"Library"}, parser: "newParser"}), "newParser is not an exported function of github.com/onichandame/struct-graphql/cmd/struct-graphql/internal/testmodels")
+		assert.EqualError(t, generate(&config{dir: "internal/testmodels"}), "no types given by -type or -input")
+	})
+}
@@ -0,0 +1,461 @@
+package structgraphql
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	goutils "github.com/onichandame/go-utils"
+)
+
+// prefix of the variables declaring generated types, e.g. GraphQLUser
+const generatedPrefix = "GraphQL"
+
+// write the source of package pkgName, imported as pkgPath, declaring every type the parser has generated as graphql-go values, e.g. GraphQLUser.
+// the declared types need no reflection at startup and resolve fields like the parsed ones.
+// fields resolved by methods, entries and scalars added with AddScalar cannot be generated
+func (parser *Parser) GenerateGo(pkgPath, pkgName string) (res []byte, err error) {
+	defer goutils.RecoverToErr(&err)
+	// generating resolves the thunks of the types like building a schema does
+	parser.mu.Lock()
+	defer parser.mu.Unlock()
+	gen := goGenerator{parser: parser, pkgPath: pkgPath, imports: make(map[string]string)}
+	return gen.generate(pkgName)
+}
+
+type goGenerator struct {
+	parser  *Parser
+	pkgPath string
+	// import paths by name
+	imports map[string]string
+}
+
+func (gen *goGenerator) generate(pkgName string) ([]byte, error) {
+	types := collectTypes(gen.parser.namedTypes())
+	sort.Slice(types, func(i, j int) bool {
+		if kindOrder(types[i]) != kindOrder(types[j]) {
+			return kindOrder(types[i]) < kindOrder(types[j])
+		}
+		return types[i].Name() < types[j].Name()
+	})
+	var decls, inits, helpers bytes.Buffer
+	var declared []string
+	for _, t := range types {
+		if _, ok := gen.knownType(t); ok {
+			continue
+		}
+		goType := gen.goType(t)
+		name := generatedPrefix + t.Name()
+		declared = append(declared, name)
+		switch t := t.(type) {
+		case *graphql.Scalar:
+			fmt.Fprintf(&decls, "%v *graphql.Scalar\n", name)
+			gen.writeScalar(&inits, t, goType)
+		case *graphql.Enum:
+			fmt.Fprintf(&decls, "%v *graphql.Enum\n", name)
+			gen.writeEnum(&inits, t, goType)
+		case *graphql.Interface:
+			fmt.Fprintf(&decls, "%v *graphql.Interface\n", name)
+			gen.writeInterface(&inits, t, types)
+		case *graphql.Object:
+			fmt.Fprintf(&decls, "%v *graphql.Object\n", name)
+			gen.writeObject(&inits, &helpers, t, goType)
+		case *graphql.Union:
+			fmt.Fprintf(&decls, "%v *graphql.Union\n", name)
+			gen.writeUnion(&inits, t)
+		case *graphql.InputObject:
+			fmt.Fprintf(&decls, "%v *graphql.InputObject\n", name)
+			gen.writeInputObject(&inits, t, goType)
+		}
+	}
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "var (\n%v)\n\n", decls.String())
+	body.WriteString("// every type declared in this file, e.g. for graphql.SchemaConfig.Types\nvar GraphQLTypes []graphql.Type\n\n")
+	fmt.Fprintf(&body, "func init() {\n%vGraphQLTypes = []graphql.Type{%v}\n}\n", inits.String(), strings.Join(declared, ", "))
+	body.Write(helpers.Bytes())
+	gen.imports["graphql"] = "github.com/graphql-go/graphql"
+	return gen.source(pkgName, body.Bytes())
+}
+
+// the formatted source of package pkgName importing the packages qualified names were taken from
+func (gen *goGenerator) source(pkgName string, body []byte) ([]byte, error) {
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by struct-graphql. DO NOT EDIT.\n\npackage %v\n\n", pkgName)
+	var names []string
+	for name := range gen.imports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	src.WriteString("import (\n")
+	for _, name := range names {
+		if name == path.Base(gen.imports[name]) {
+			fmt.Fprintf(&src, "%q\n", gen.imports[name])
+		} else {
+			fmt.Fprintf(&src, "%v %q\n", name, gen.imports[name])
+		}
+	}
+	src.WriteString(")\n\n")
+	src.Write(body)
+	return format.Source(src.Bytes())
+}
+
+// types are declared before the types referencing them outside of thunks
+func kindOrder(t graphql.Type) int {
+	switch t.(type) {
+	case *graphql.Scalar:
+		return 0
+	case *graphql.Enum:
+		return 1
+	case *graphql.Interface:
+		return 2
+	case *graphql.Object:
+		return 3
+	case *graphql.Union:
+		return 4
+	}
+	return 5
+}
+
+// the expressions of the types declared by graphql-go and by this package
+func (gen *goGenerator) knownType(t graphql.Type) (string, bool) {
+	known := map[graphql.Type]string{
+		graphql.String:   "graphql.String",
+		graphql.Int:      "graphql.Int",
+		graphql.Float:    "graphql.Float",
+		graphql.Boolean:  "graphql.Boolean",
+		graphql.ID:       "graphql.ID",
+		graphql.DateTime: "graphql.DateTime",
+		JSONScalar:       "JSONScalar",
+		Int64Scalar:      "Int64Scalar",
+		BigIntScalar:     "BigIntScalar",
+		DecimalScalar:    "DecimalScalar",
+		DurationScalar:   "DurationScalar",
+		DateScalar:       "DateScalar",
+		URLScalar:        "URLScalar",
+		UUIDScalar:       "UUIDScalar",
+		Base64Scalar:     "Base64Scalar",
+	}
+	expr, ok := known[t]
+	if ok && !strings.HasPrefix(expr, "graphql.") {
+		expr = gen.qualify(reflect.TypeOf(Parser{}).PkgPath(), expr)
+	}
+	return expr, ok
+}
+
+// the Go type a named graphql type was parsed from
+func (gen *goGenerator) goType(t graphql.Type) reflect.Type {
+	named, ok := gen.parser.names[t.Name()]
+	if !ok || named.gqlType != t {
+		panic(newParseError(nil, t.Name(), "%v was not generated by the parser", describeType(t)))
+	}
+	if gen.parser.entries[named.goType] == t || gen.parser.inputEntries[named.goType] == t {
+		panic(newParseError(named.goType, rootPath(named.goType), "entries cannot be generated"))
+	}
+	return named.goType
+}
+
+// the expression of a possibly wrapped type
+func (gen *goGenerator) typeRef(t graphql.Type) string {
+	switch t := t.(type) {
+	case *graphql.NonNull:
+		return "graphql.NewNonNull(" + gen.typeRef(t.OfType) + ")"
+	case *graphql.List:
+		return "graphql.NewList(" + gen.typeRef(t.OfType) + ")"
+	}
+	if expr, ok := gen.knownType(t); ok {
+		return expr
+	}
+	return generatedPrefix + t.Name()
+}
+
+// the expression of named Go type t
+func (gen *goGenerator) goTypeRef(t reflect.Type, path string) string {
+	if t.Name() == "" {
+		panic(newParseError(t, path, "unnamed types cannot be generated"))
+	}
+	if t.PkgPath() == "" {
+		return t.Name()
+	}
+	if !ast.IsExported(t.Name()) && t.PkgPath() != gen.pkgPath {
+		panic(newParseError(t, path, "unexported types of other packages cannot be generated"))
+	}
+	return gen.qualify(t.PkgPath(), t.Name())
+}
+
+// name of the generated function returning the source of an object as its Go type
+func sourceFuncName(object *graphql.Object) string {
+	return "graphQL" + object.Name() + "Source"
+}
+
+// qualify name with the package imported as pkgPath
+func (gen *goGenerator) qualify(pkgPath, name string) string {
+	if pkgPath == gen.pkgPath {
+		return name
+	}
+	for importName, importPath := range gen.imports {
+		if importPath == pkgPath {
+			return importName + "." + name
+		}
+	}
+	base := strings.Map(func(r rune) rune {
+		if r == '_' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' {
+			return r
+		}
+		return '_'
+	}, path.Base(pkgPath))
+	if pkgPath == reflect.TypeOf(Parser{}).PkgPath() {
+		base = "structgraphql"
+	}
+	importName := base
+	for i := 2; ; i++ {
+		if _, ok := gen.imports[importName]; !ok && importName != "graphql" {
+			break
+		}
+		importName = base + strconv.Itoa(i)
+	}
+	gen.imports[importName] = pkgPath
+	return importName + "." + name
+}
+
+func (gen *goGenerator) writeScalar(w *bytes.Buffer, scalar *graphql.Scalar, goType reflect.Type) {
+	base, ok := gen.parser.scalarBases[scalar]
+	if !ok {
+		panic(newParseError(goType, rootPath(goType), "scalars added with AddScalar cannot be generated"))
+	}
+	baseRef := gen.typeRef(base)
+	fmt.Fprintf(w, "%v%v = graphql.NewScalar(graphql.ScalarConfig{\nName: %q,\n", generatedPrefix, scalar.Name(), scalar.Name())
+	writeDescription(w, scalar.Description())
+	fmt.Fprintf(w, "Serialize: %[1]v.Serialize,\nParseValue: %[1]v.ParseValue,\nParseLiteral: %[1]v.ParseLiteral,\n})\n", baseRef)
+}
+
+func (gen *goGenerator) writeEnum(w *bytes.Buffer, enum *graphql.Enum, goType reflect.Type) {
+	fmt.Fprintf(w, "%v%v = graphql.NewEnum(graphql.EnumConfig{\nName: %q,\n", generatedPrefix, enum.Name(), enum.Name())
+	writeDescription(w, enum.Description())
+	w.WriteString("Values: graphql.EnumValueConfigMap{\n")
+	values := append([]*graphql.EnumValueDefinition{}, enum.Values()...)
+	sort.Slice(values, func(i, j int) bool { return values[i].Name < values[j].Name })
+	for _, value := range values {
+		fmt.Fprintf(w, "%q: &graphql.EnumValueConfig{\nValue: %v,\n", value.Name, gen.goValue(value.Value, goType, rootPath(goType)+"."+value.Name))
+		writeDescription(w, value.Description)
+		writeDeprecation(w, value.DeprecationReason)
+		w.WriteString("},\n")
+	}
+	w.WriteString("},\n})\n")
+}
+
+func (gen *goGenerator) writeInterface(w *bytes.Buffer, iface *graphql.Interface, types []graphql.Type) {
+	fmt.Fprintf(w, "%v%v = graphql.NewInterface(graphql.InterfaceConfig{\nName: %q,\n", generatedPrefix, iface.Name(), iface.Name())
+	writeDescription(w, iface.Description())
+	// the resolvers of interface fields are never called, those of the implementing objects are
+	w.WriteString("Fields: graphql.FieldsThunk(func() graphql.Fields {\nreturn graphql.Fields{\n")
+	fields := iface.Fields()
+	for _, name := range sortedFieldNames(fields) {
+		gen.writeField(w, fields[name], "")
+	}
+	w.WriteString("}\n}),\n")
+	var objects []graphql.Type
+	for _, t := range types {
+		if object, ok := t.(*graphql.Object); ok {
+			for _, implemented := range object.Interfaces() {
+				if implemented == iface {
+					objects = append(objects, object)
+				}
+			}
+		}
+	}
+	gen.writeResolveType(w, objects)
+	w.WriteString("})\n")
+}
+
+func (gen *goGenerator) writeUnion(w *bytes.Buffer, union *graphql.Union) {
+	fmt.Fprintf(w, "%v%v = graphql.NewUnion(graphql.UnionConfig{\nName: %q,\n", generatedPrefix, union.Name(), union.Name())
+	writeDescription(w, union.Description())
+	var members []string
+	var objects []graphql.Type
+	for _, member := range union.Types() {
+		members = append(members, gen.typeRef(member))
+		objects = append(objects, member)
+	}
+	fmt.Fprintf(w, "Types: []*graphql.Object{%v},\n", strings.Join(members, ", "))
+	gen.writeResolveType(w, objects)
+	w.WriteString("})\n")
+}
+
+// resolve the objects from the Go types of the values, like the parsed interfaces and unions do
+func (gen *goGenerator) writeResolveType(w *bytes.Buffer, objects []graphql.Type) {
+	w.WriteString("ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {\nswitch p.Value.(type) {\n")
+	for _, object := range objects {
+		goType := gen.goTypeRef(gen.goType(object), object.Name())
+		fmt.Fprintf(w, "case %[1]v, *%[1]v:\nreturn %[2]v\n", goType, gen.typeRef(object))
+	}
+	w.WriteString("}\nreturn nil\n},\n")
+}
+
+func (gen *goGenerator) writeObject(w, helpers *bytes.Buffer, object *graphql.Object, goType reflect.Type) {
+	fmt.Fprintf(w, "%v%v = graphql.NewObject(graphql.ObjectConfig{\nName: %q,\n", generatedPrefix, object.Name(), object.Name())
+	writeDescription(w, typeDescription(object))
+	if interfaces := object.Interfaces(); len(interfaces) > 0 {
+		var refs []string
+		for _, iface := range interfaces {
+			refs = append(refs, gen.typeRef(iface))
+		}
+		fmt.Fprintf(w, "Interfaces: []*graphql.Interface{%v},\n", strings.Join(refs, ", "))
+	}
+	w.WriteString("Fields: graphql.FieldsThunk(func() graphql.Fields {\nreturn graphql.Fields{\n")
+	structFields := gen.outputFields(goType)
+	fields := object.Fields()
+	for _, name := range sortedFieldNames(fields) {
+		chain, ok := structFields[name]
+		if !ok {
+			panic(newParseError(goType, rootPath(goType), "field %v is resolved by a method, which cannot be generated", name))
+		}
+		gen.writeField(w, fields[name], gen.resolveStructField(object, goType, chain))
+	}
+	w.WriteString("}\n}),\n})\n")
+	goTypeRef := gen.goTypeRef(goType, rootPath(goType))
+	fmt.Fprintf(helpers, `
+func %[1]v(source interface{}) (*%[2]v, bool) {
+	switch source := source.(type) {
+	case *%[2]v:
+		return source, source != nil
+	case %[2]v:
+		return &source, true
+	}
+	return nil, false
+}
+`, sourceFuncName(object), goTypeRef)
+}
+
+func (gen *goGenerator) writeField(w *bytes.Buffer, field *graphql.FieldDefinition, resolve string) {
+	fmt.Fprintf(w, "%q: &graphql.Field{\nType: %v,\n", field.Name, gen.typeRef(field.Type))
+	writeDescription(w, field.Description)
+	writeDeprecation(w, field.DeprecationReason)
+	if resolve != "" {
+		fmt.Fprintf(w, "Resolve: %v,\n", resolve)
+	}
+	w.WriteString("},\n")
+}
+
+// the struct fields of the output fields of t, from the outermost embedded field to the field itself
+func (gen *goGenerator) outputFields(t reflect.Type) map[string][]reflect.StructField {
+	res := make(map[string][]reflect.StructField)
+	var loadStruct func(st reflect.Type, chain []reflect.StructField)
+	loadStruct = func(st reflect.Type, chain []reflect.StructField) {
+		for i := 0; i < st.NumField(); i++ {
+			field := st.Field(i)
+			if gen.parser.isOmitted(&field, TAG_OUTPUT) {
+				continue
+			}
+			fieldChain := append(append([]reflect.StructField{}, chain...), field)
+			if field.Anonymous {
+				loadStruct(getType(field.Type), fieldChain)
+			} else if hasEntries(&field) {
+				panic(newParseError(t, fieldPath(rootPath(t), &field, 0), "entries cannot be generated"))
+			} else {
+				res[gen.parser.getFieldName(&field)] = fieldChain
+			}
+		}
+	}
+	loadStruct(t, nil)
+	return res
+}
+
+// promoted fields of nil embedded pointers resolve to null, and sources of other types to their fields of the same name, like the parsed resolvers do
+func (gen *goGenerator) resolveStructField(object *graphql.Object, t reflect.Type, chain []reflect.StructField) string {
+	var w bytes.Buffer
+	fmt.Fprintf(&w, "func(p graphql.ResolveParams) (interface{}, error) {\nsource, ok := %v(p.Source)\nif !ok {\nreturn graphql.DefaultResolveFn(p)\n}\n", sourceFuncName(object))
+	selector := "source"
+	for _, field := range chain {
+		selector += "." + field.Name
+		if !field.Anonymous {
+			break
+		}
+		switch {
+		case field.Type.Kind() != reflect.Ptr:
+		case field.Type.Elem().Kind() == reflect.Ptr:
+			panic(newParseError(t, rootPath(t)+"."+field.Name, "embedded pointers to pointers cannot be generated"))
+		default:
+			fmt.Fprintf(&w, "if %v == nil {\nreturn nil, nil\n}\n", selector)
+		}
+	}
+	fmt.Fprintf(&w, "return %v, nil\n}", selector)
+	return w.String()
+}
+
+func (gen *goGenerator) writeInputObject(w *bytes.Buffer, object *graphql.InputObject, goType reflect.Type) {
+	fmt.Fprintf(w, "%v%v = graphql.NewInputObject(graphql.InputObjectConfig{\nName: %q,\n", generatedPrefix, object.Name(), object.Name())
+	writeDescription(w, object.Description())
+	w.WriteString("Fields: graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap {\nreturn graphql.InputObjectConfigFieldMap{\n")
+	fields := object.Fields()
+	for _, name := range sortedFieldNames(fields) {
+		field := fields[name]
+		fmt.Fprintf(w, "%q: &graphql.InputObjectFieldConfig{\nType: %v,\n", name, gen.typeRef(field.Type))
+		writeDescription(w, field.Description())
+		if field.DefaultValue != nil {
+			fmt.Fprintf(w, "DefaultValue: %v,\n", gen.goValue(field.DefaultValue, goType, rootPath(goType)+"."+name))
+		}
+		w.WriteString("},\n")
+	}
+	w.WriteString("}\n}),\n})\n")
+}
+
+// the Go expression of an enum value or a default. t and path locate the value in errors
+func (gen *goGenerator) goValue(value interface{}, t reflect.Type, path string) string {
+	switch value := value.(type) {
+	case nil:
+		return "nil"
+	case []interface{}:
+		var items []string
+		for _, item := range value {
+			items = append(items, gen.goValue(item, t, path))
+		}
+		return "[]interface{}{" + strings.Join(items, ", ") + "}"
+	case map[string]interface{}:
+		var items []string
+		for _, key := range sortedKeys(value) {
+			items = append(items, strconv.Quote(key)+": "+gen.goValue(value[key], t, path))
+		}
+		return "map[string]interface{}{" + strings.Join(items, ", ") + "}"
+	}
+	v := reflect.ValueOf(value)
+	var literal string
+	switch v.Kind() {
+	case reflect.Bool:
+		literal = strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		literal = strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		literal = strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		literal = strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+	case reflect.String:
+		literal = strconv.Quote(v.String())
+	default:
+		panic(newParseError(t, path, "values of type %T cannot be generated", value))
+	}
+	// untyped constants default to bool, int and string
+	switch v.Type() {
+	case reflect.TypeOf(false), reflect.TypeOf(0), reflect.TypeOf(""):
+		return literal
+	}
+	return gen.goTypeRef(v.Type(), path) + "(" + literal + ")"
+}
+
+func writeDescription(w *bytes.Buffer, description string) {
+	if description != "" {
+		fmt.Fprintf(w, "Description: %q,\n", description)
+	}
+}
+
+func writeDeprecation(w *bytes.Buffer, reason string) {
+	if reason != "" {
+		fmt.Fprintf(w, "DeprecationReason: %q,\n", reason)
+	}
+}
@@ -0,0 +1,48 @@
+package structgraphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	structgraphql "github.com/onichandame/struct-graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateGo(t *testing.T) {
+	t.Run("declares the parsed types", func(t *testing.T) {
+		parser := structgraphql.NewParser(structgraphql.WithScalars())
+		parser.ParseOutput(Release{})
+		parser.ParseInput(ShelfArgs{})
+		src, err := parser.GenerateGo(reflect.TypeOf(Release{}).PkgPath(), "structgraphql_test")
+		assert.Nil(t, err)
+		assert.Contains(t, string(src), "package structgraphql_test\n")
+		assert.Contains(t, string(src), `structgraphql "github.com/onichandame/struct-graphql"`)
+		assert.Contains(t, string(src), "GraphQLRelease   *graphql.Object\n")
+		assert.Contains(t, string(src), "Type: graphql.NewNonNull(structgraphql.DurationScalar),\n")
+		assert.Contains(t, string(src), "return source.Timeout, nil\n")
+		assert.Contains(t, string(src), "func graphQLReleaseSource(source interface{}) (*Release, bool) {\n")
+		assert.Contains(t, string(src), "Value:       Priority(1),\n")
+		assert.Contains(t, string(src), "DefaultValue: []interface{}{\"a\", \"b\"},\n")
+	})
+	t.Run("rejects what cannot be generated", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		parser.ParseOutput(Customer{})
+		_, err := parser.GenerateGo("example.com/models", "models")
+		assert.IsType(t, new(structgraphql.ParseError), err)
+		assert.Contains(t, err.Error(), "is resolved by a method, which cannot be generated")
+
+		parser = structgraphql.NewParser()
+		parser.ParseOutput(Resource{})
+		_, err = parser.GenerateGo("example.com/models", "models")
+		assert.Contains(t, err.Error(), "entries cannot be generated")
+
+		parser = structgraphql.NewParser()
+		parser.AddScalar(Level(""), graphql.NewScalar(graphql.ScalarConfig{Name: "Level", Serialize: graphql.String.Serialize}))
+		parser.ParseOutput(struct {
+			Level Level `graphql:"level"`
+		}{})
+		_, err = parser.GenerateGo("example.com/models", "models")
+		assert.Contains(t, err.Error(), "scalars added with AddScalar cannot be generated")
+	})
+}
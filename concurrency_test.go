@@ -0,0 +1,72 @@
+package structgraphql_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	structgraphql "github.com/onichandame/struct-graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrency(t *testing.T) {
+	t.Run("parses concurrently with stable identities", func(t *testing.T) {
+		parser := structgraphql.NewParser(structgraphql.WithInputSuffix("Input"))
+		type Args struct {
+			Status Status `graphql:"status"`
+			Post   Post   `graphql:"post"`
+		}
+		const workers = 16
+		type result struct {
+			user, post, input, priority, scalar graphql.Type
+			node, search                        graphql.Type
+			args                                graphql.FieldConfigArgument
+		}
+		results := make([]result, workers)
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				res := &results[i]
+				parser.AddEnumByValues(Status(0), map[string]interface{}{"ACTIVE": StatusActive, "INACTIVE": StatusInactive})
+				parser.AddScalar(Str(""), graphql.NewScalar(graphql.ScalarConfig{Name: "Str", Serialize: graphql.String.Serialize}))
+				res.node = parser.AddInterface((*Node)(nil))
+				res.search = parser.AddUnion((*SearchResult)(nil), Book{}, Author{})
+				res.scalar = parser.ParseOutput(Str(""))
+				res.user = parser.ParseOutput(new(User))
+				res.post = parser.ParseOutput(new(Post))
+				res.input = parser.ParseInput(new(Post))
+				res.priority = parser.ParseInput(PriorityLow)
+				res.args = parser.ParseArgs(new(Args))
+				parser.Field(func(ctx context.Context, args Args) (*User, error) { return nil, nil })
+				parser.SubscriptionField(func(ctx context.Context) <-chan *Post { return nil })
+				var args Args
+				assert.Nil(t, parser.DecodeArgs(map[string]interface{}{"status": StatusActive, "post": map[string]interface{}{"title": "hello"}}, &args))
+				assert.Equal(t, "hello", args.Post.Title)
+				nodes := &NodeResolver{nodes: []Node{&Book{Entity: Entity{ID: 1}, Title: "Dune"}}}
+				schema, err := structgraphql.NewSchemaBuilder(parser).
+					Queries(nodes).
+					Query("user", func() *User { return &User{Name: "ann"} }).
+					Build()
+				assert.Nil(t, err)
+				query := graphql.Do(graphql.Params{Schema: schema, RequestString: `{user{name} Nodes{... on Book{title}}}`})
+				assert.Nil(t, query.Errors)
+			}(i)
+		}
+		wg.Wait()
+		for _, res := range results[1:] {
+			assert.Same(t, results[0].user, res.user)
+			assert.Same(t, results[0].post, res.post)
+			assert.Same(t, results[0].input, res.input)
+			assert.Same(t, results[0].priority, res.priority)
+			assert.Same(t, results[0].scalar, res.scalar)
+			assert.Same(t, results[0].node, res.node)
+			assert.Same(t, results[0].search, res.search)
+			for _, arg := range []string{"status", "post"} {
+				assert.Same(t, graphql.GetNullable(results[0].args[arg].Type), graphql.GetNullable(res.args[arg].Type))
+			}
+		}
+	})
+}
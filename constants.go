@@ -1,6 +1,18 @@
 package structgraphql
 
 const (
-	TAG_PREFIX   = "graphql"
-	TAG_NULLABLE = "nullable"
+	TAG_PREFIX        = "graphql"
+	TAG_NULLABLE      = "nullable"
+	TAG_NONNULL       = "nonnull"
+	TAG_NULLABLEITEMS = "nullableitems"
+	TAG_NONNULLITEMS  = "nonnullitems"
+	TAG_DEFAULT       = "default"
+	TAG_ENTRIES       = "entries"
+	TAG_DESCRIPTION   = "gqldesc"
+	TAG_DEPRECATED    = "gqldeprecated"
+	TAG_OUTPUT        = "gqloutput"
+	TAG_INPUT         = "gqlinput"
+	TAG_OMIT          = "-"
+	TAG_JSON          = "json"
+	TAG_OMITEMPTY     = "omitempty"
 )
@@ -0,0 +1,225 @@
+package structgraphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// decode the args received by a resolver into the struct passed to ParseArgs
+func (parser *Parser) DecodeArgs(args map[string]interface{}, ent interface{}) error {
+	v := reflect.ValueOf(ent)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("args must be decoded into a non-nil pointer but got %T", ent)
+	}
+	v = allocValue(v)
+	if v.Kind() != reflect.Struct {
+		return newParseError(v.Type(), rootPath(v.Type()), "args must be decoded into a struct")
+	}
+	return parser.decodeStruct(args, v, rootPath(v.Type()))
+}
+
+func (parser *Parser) decodeStruct(src map[string]interface{}, dst reflect.Value, path string) error {
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if parser.isOmitted(&field, TAG_INPUT) {
+			continue
+		}
+		if field.Anonymous {
+			if err := parser.decodeStruct(src, allocValue(dst.Field(i)), path); err != nil {
+				return err
+			}
+		} else if value, ok := src[parser.getFieldName(&field)]; ok {
+			if err := parser.decodeValue(value, dst.Field(i), fieldPath(path, &field, 0)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (parser *Parser) decodeValue(src interface{}, dst reflect.Value, path string) error {
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	srcValue := reflect.ValueOf(src)
+	if srcValue.Type().AssignableTo(dst.Type()) {
+		dst.Set(srcValue)
+		return nil
+	}
+	// scalars such as BigIntScalar parse values as pointers
+	if srcValue.Kind() == reflect.Ptr && !srcValue.IsNil() && srcValue.Elem().Type().AssignableTo(dst.Type()) {
+		dst.Set(srcValue.Elem())
+		return nil
+	}
+	if dst.Type() == reflect.TypeOf(json.RawMessage{}) {
+		raw, err := json.Marshal(src)
+		if err != nil {
+			return newParseError(dst.Type(), path, "cannot decode value of type %T: %v", src, err)
+		}
+		dst.SetBytes(raw)
+		return nil
+	}
+	switch dst.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(dst.Type().Elem())
+		if err := parser.decodeValue(src, elem.Elem(), path); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	case reflect.Slice:
+		if srcValue.Kind() != reflect.Slice {
+			break
+		}
+		slice := reflect.MakeSlice(dst.Type(), srcValue.Len(), srcValue.Len())
+		for i := 0; i < srcValue.Len(); i++ {
+			if err := parser.decodeValue(srcValue.Index(i).Interface(), slice.Index(i), path+"[]"); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+		return nil
+	case reflect.Struct:
+		if m, ok := src.(map[string]interface{}); ok {
+			return parser.decodeStruct(m, dst, path)
+		}
+	case reflect.Map:
+		return parser.decodeMap(srcValue, dst, path)
+	}
+	if converted, ok := convertValue(srcValue, dst.Type()); ok {
+		dst.Set(converted)
+		return nil
+	}
+	return newParseError(dst.Type(), path, "cannot decode value of type %T", src)
+}
+
+// maps are received either as JSON objects or as lists of entries
+func (parser *Parser) decodeMap(src reflect.Value, dst reflect.Value, path string) error {
+	res := reflect.MakeMap(dst.Type())
+	set := func(key, value interface{}) error {
+		k := reflect.New(dst.Type().Key()).Elem()
+		if err := parser.decodeValue(key, k, path+"{key}"); err != nil {
+			return err
+		}
+		v := reflect.New(dst.Type().Elem()).Elem()
+		if err := parser.decodeValue(value, v, path+"{value}"); err != nil {
+			return err
+		}
+		res.SetMapIndex(k, v)
+		return nil
+	}
+	switch src.Kind() {
+	case reflect.Map:
+		for _, key := range src.MapKeys() {
+			if err := set(key.Interface(), src.MapIndex(key).Interface()); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < src.Len(); i++ {
+			entry, ok := src.Index(i).Interface().(map[string]interface{})
+			if !ok {
+				return newParseError(dst.Type(), path, "cannot decode entry of type %T", src.Index(i).Interface())
+			}
+			if err := set(entry["key"], entry["value"]); err != nil {
+				return err
+			}
+		}
+	default:
+		return newParseError(dst.Type(), path, "cannot decode value of type %v", src.Type())
+	}
+	dst.Set(res)
+	return nil
+}
+
+// allocate nil pointers until a non-pointer value is reached
+func allocValue(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// only conversions between values of the same family are allowed, so that e.g. an int never becomes a string
+func convertValue(v reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	switch {
+	case isNumberKind(v.Kind()) && isNumberKind(t.Kind()):
+		converted := v.Convert(t)
+		if isFloatKind(t.Kind()) {
+			// floats are rounded to the precision of the target, only overflowing it is rejected
+			return converted, !math.IsInf(converted.Float(), 0) || isFloatKind(v.Kind()) && math.IsInf(v.Float(), 0)
+		}
+		// reject conversions to integers that overflow or truncate
+		if isFloatKind(v.Kind()) && v.Float() != math.Trunc(v.Float()) {
+			return v, false
+		}
+		if converted.Convert(v.Type()).Interface() != v.Interface() || isNegative(converted) != isNegative(v) {
+			return v, false
+		}
+		return converted, true
+	case v.Kind() == reflect.String && isNumberKind(t.Kind()) && isID(t):
+		// IDs are always received as strings
+		id := reflect.New(t).Elem()
+		var err error
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			var n int64
+			n, err = strconv.ParseInt(v.String(), 10, t.Bits())
+			id.SetInt(n)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			var n uint64
+			n, err = strconv.ParseUint(v.String(), 10, t.Bits())
+			id.SetUint(n)
+		default:
+			var n float64
+			n, err = strconv.ParseFloat(v.String(), t.Bits())
+			id.SetFloat(n)
+		}
+		return id, err == nil
+	case isBytes(v.Type()) && isBytes(t) && t.Kind() == reflect.Array:
+		// Base64 values are received as slices
+		if v.Len() != t.Len() {
+			return v, false
+		}
+		array := reflect.New(t).Elem()
+		reflect.Copy(array, v)
+		return array, true
+	case v.Kind() == t.Kind() && (t.Kind() == reflect.String || t.Kind() == reflect.Bool || t.Kind() == reflect.Struct || t.Kind() == reflect.Array):
+		if v.Type().ConvertibleTo(t) {
+			return v.Convert(t), true
+		}
+	}
+	return v, false
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+func isNegative(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() < 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() < 0
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package structgraphql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	structgraphql "github.com/onichandame/struct-graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+type Status int
+
+const (
+	StatusActive Status = iota + 1
+	StatusInactive
+)
+
+func TestDecodeArgs(t *testing.T) {
+	t.Run("decodes resolver args", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		parser.AddEnumByValues(Status(0), map[string]interface{}{"ACTIVE": StatusActive, "INACTIVE": StatusInactive})
+		type Embedded struct {
+			Limit int8 `graphql:"limit"`
+		}
+		type Input struct {
+			Name Str      `graphql:"name"`
+			Tags []string `graphql:"tags"`
+		}
+		type Args struct {
+			Embedded
+			Input    *Input     `graphql:"input"`
+			Inputs   [][]Input  `graphql:"inputs"`
+			Status   Status     `graphql:"status"`
+			ID       *uint      `graphql:"id"`
+			Date     time.Time  `graphql:"date"`
+			Optional *time.Time `graphql:"optional,nullable"`
+			Ratio    float32    `graphql:"ratio"`
+		}
+		var args Args
+		schema, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "query",
+				Fields: graphql.Fields{
+					"decode": &graphql.Field{
+						Type: graphql.Boolean,
+						Args: parser.ParseArgs(new(Args)),
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							return true, parser.DecodeArgs(p.Args, &args)
+						},
+					},
+				},
+			}),
+		})
+		assert.Nil(t, err)
+		res := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{decode(limit:20,input:{name:"jimmy",tags:["a","b"]},inputs:[[{name:"a",tags:[]}]],status:INACTIVE,id:3,date:"2021-01-02T15:04:05Z",ratio:0.5)}`,
+		})
+		assert.Nil(t, res.Errors)
+		assert.Equal(t, int8(20), args.Limit)
+		assert.Equal(t, Str("jimmy"), args.Input.Name)
+		assert.Equal(t, []string{"a", "b"}, args.Input.Tags)
+		assert.Equal(t, Str("a"), args.Inputs[0][0].Name)
+		assert.Equal(t, StatusInactive, args.Status)
+		assert.Equal(t, uint(3), *args.ID)
+		assert.Equal(t, time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC), args.Date)
+		assert.Nil(t, args.Optional)
+		assert.Equal(t, float32(0.5), args.Ratio)
+	})
+	t.Run("reports mismatches", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		type Input struct {
+			Count int8 `graphql:"count"`
+		}
+		type Args struct {
+			Input []Input `graphql:"input"`
+		}
+		var args Args
+		err := parser.DecodeArgs(map[string]interface{}{"input": []interface{}{map[string]interface{}{"count": 300}}}, &args)
+		var parseErr *structgraphql.ParseError
+		assert.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, "Args.Input[].Count", parseErr.Path)
+		assert.Error(t, parser.DecodeArgs(map[string]interface{}{"input": "str"}, &args))
+		assert.Error(t, parser.DecodeArgs(map[string]interface{}{}, args))
+		var id struct {
+			ID ID `graphql:"id"`
+		}
+		assert.Nil(t, parser.DecodeArgs(map[string]interface{}{"id": "42"}, &id))
+		assert.Equal(t, ID(42), id.ID)
+		assert.Error(t, parser.DecodeArgs(map[string]interface{}{"id": "-1"}, &id))
+		var str string
+		assert.Error(t, parser.DecodeArgs(map[string]interface{}{}, &str))
+	})
+	t.Run("rounds floats", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		type Args struct {
+			Ratio float32 `graphql:"ratio"`
+			Count int     `graphql:"count"`
+		}
+		var args Args
+		assert.Nil(t, parser.DecodeArgs(map[string]interface{}{"ratio": 0.1, "count": 3.0}, &args))
+		assert.Equal(t, float32(0.1), args.Ratio)
+		assert.Equal(t, 3, args.Count)
+		assert.Error(t, parser.DecodeArgs(map[string]interface{}{"ratio": 1e300}, &args))
+		assert.Error(t, parser.DecodeArgs(map[string]interface{}{"count": 2.5}, &args))
+	})
+}
@@ -0,0 +1,114 @@
+package structgraphql
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/structtag"
+	"github.com/graphql-go/graphql"
+)
+
+// the default option of a field takes precedence over the default of its type
+func getFieldDefault(field *reflect.StructField, t reflect.Type, input graphql.Type, path string) interface{} {
+	if text, ok := getDefaultOption(field); ok {
+		return parseDefault(text, t, input, path)
+	}
+	return getDefault(t)
+}
+
+// graphql-go requires non-null inputs even when they have a default, so inputs with a default are nullable for it to apply
+func nullableWithDefault(input graphql.Type, defaultValue interface{}) graphql.Type {
+	if nonNull, ok := input.(*graphql.NonNull); ok && defaultValue != nil {
+		return nonNull.OfType
+	}
+	return input
+}
+
+// the default option must be the last option of the tag so that lists like default=[1,2] can contain commas
+func getDefaultOption(field *reflect.StructField) (string, bool) {
+	tags, _ := structtag.Parse(string(field.Tag))
+	if tags != nil {
+		tag, _ := tags.Get(TAG_PREFIX)
+		if tag != nil {
+			for i, option := range tag.Options {
+				if strings.HasPrefix(option, TAG_DEFAULT+"=") {
+					return strings.TrimPrefix(strings.Join(tag.Options[i:], ","), TAG_DEFAULT+"="), true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// parse the text of a default value into the value its input type would have parsed from a request
+func parseDefault(text string, t reflect.Type, input graphql.Type, path string) interface{} {
+	text = strings.TrimSpace(text)
+	switch input := input.(type) {
+	case *graphql.NonNull:
+		return parseDefault(text, t, input.OfType, path)
+	case *graphql.List:
+		// like graphql, a single value is accepted as a list of one
+		if !strings.HasPrefix(text, "[") || !strings.HasSuffix(text, "]") {
+			return []interface{}{parseDefault(text, t, input.OfType, path)}
+		}
+		res := []interface{}{}
+		for _, item := range splitList(text[1 : len(text)-1]) {
+			res = append(res, parseDefault(item, t, input.OfType, path))
+		}
+		return res
+	case *graphql.Enum:
+		for _, value := range input.Values() {
+			if value.Name == text {
+				return value.Value
+			}
+		}
+		panic(newParseError(t, path, "default %v is not a value of enum %v", text, input.Name()))
+	case *graphql.Scalar:
+		var value interface{} = text
+		var err error
+		switch t.Kind() {
+		// integers keep 64 bits so that the scalar rejects those out of its range instead of them wrapping around
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			value, err = strconv.ParseInt(text, 10, t.Bits())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			value, err = strconv.ParseUint(text, 10, t.Bits())
+		case reflect.Float32, reflect.Float64:
+			value, err = strconv.ParseFloat(text, t.Bits())
+		case reflect.Bool:
+			value, err = strconv.ParseBool(text)
+		}
+		if err == nil {
+			value = input.ParseValue(value)
+		}
+		if err != nil || value == nil {
+			panic(newParseError(t, path, "default %v is not a valid %v", text, input.Name()))
+		}
+		return value
+	default:
+		panic(newParseError(t, path, "default values are not supported for %v", input))
+	}
+}
+
+// split the items of a list on commas that are not nested in another list
+func splitList(text string) []string {
+	var res []string
+	if strings.TrimSpace(text) == "" {
+		return res
+	}
+	var depth, start int
+	for i, c := range text {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				res = append(res, text[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(res, text[start:])
+}
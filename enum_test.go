@@ -0,0 +1,76 @@
+package structgraphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	structgraphql "github.com/onichandame/struct-graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+type Priority int
+
+const (
+	PriorityLow Priority = iota
+	PriorityHigh
+	PriorityUrgent
+)
+
+func (Priority) GetValues() map[string]interface{} {
+	return map[string]interface{}{"Low": PriorityLow, "High": PriorityHigh, "Urgent": PriorityUrgent}
+}
+func (Priority) GetValueDescriptions() map[string]string {
+	return map[string]string{"High": "handled first"}
+}
+func (Priority) GetValueDeprecations() map[string]string {
+	return map[string]string{"Urgent": "use High"}
+}
+
+type Level string
+
+func (Level) GetValues() map[string]interface{} {
+	return map[string]interface{}{"Debug": "debug", "Info": 1}
+}
+
+func TestEnumerated(t *testing.T) {
+	t.Run("generates enums from their values", func(t *testing.T) {
+		parser := structgraphql.NewParser(structgraphql.WithEnumValueName(structgraphql.ScreamingSnakeCase))
+		enum := parser.ParseOutput(PriorityLow).(*graphql.Enum)
+		assert.Equal(t, "Priority", enum.Name())
+		assert.Equal(t, enum, parser.ParseInput(PriorityLow))
+		values := make(map[string]*graphql.EnumValueDefinition)
+		for _, value := range enum.Values() {
+			values[value.Name] = value
+		}
+		assert.Equal(t, []string{"HIGH", "LOW", "URGENT"}, sortedKeys(values))
+		assert.Equal(t, PriorityHigh, values["HIGH"].Value)
+		assert.Equal(t, "handled first", values["HIGH"].Description)
+		assert.Equal(t, "use High", values["URGENT"].DeprecationReason)
+		assert.Empty(t, values["LOW"].DeprecationReason)
+	})
+	t.Run("generates enums for inputs first", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		enum := parser.ParseInput(PriorityLow)
+		assert.IsType(t, &graphql.Enum{}, enum)
+		assert.Equal(t, enum, parser.ParseOutput(PriorityLow))
+	})
+	t.Run("resolves and decodes values", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		type Args struct {
+			Priority Priority `graphql:"priority"`
+		}
+		schema, err := structgraphql.NewSchemaBuilder(parser).
+			Query("raise", func(args Args) Priority { return args.Priority + 1 }).
+			Build()
+		assert.Nil(t, err)
+		res := graphql.Do(graphql.Params{Schema: schema, RequestString: `{raise(priority:Low)}`})
+		assert.Nil(t, res.Errors)
+		assert.Equal(t, map[string]interface{}{"raise": "High"}, res.Data)
+	})
+	t.Run("converts values to the enum type", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		_, err := parser.TryParseOutput(Level(""))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "value Info of type int is not a structgraphql_test.Level")
+	})
+}
@@ -0,0 +1,34 @@
+package structgraphql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ParseError reports the Go type and field path at which parsing failed
+type ParseError struct {
+	Type   reflect.Type
+	Path   string
+	Reason string
+}
+
+func (err *ParseError) Error() string {
+	return fmt.Sprintf("failed to parse %v at %v: %v", err.Type, err.Path, err.Reason)
+}
+
+func newParseError(t reflect.Type, path string, format string, args ...interface{}) *ParseError {
+	return &ParseError{Type: t, Path: path, Reason: fmt.Sprintf(format, args...)}
+}
+
+func rootPath(t reflect.Type) string {
+	if t.Name() != "" {
+		return t.Name()
+	}
+	return t.String()
+}
+
+// the path of a field is appended with [] for each slice dimension, e.g. Order.Items[].Product
+func fieldPath(path string, field *reflect.StructField, sliceDims int) string {
+	return path + "." + field.Name + strings.Repeat("[]", sliceDims)
+}
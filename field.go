@@ -0,0 +1,227 @@
+package structgraphql
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+	goutils "github.com/onichandame/go-utils"
+)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// generate a field from a resolver function of the form func([context.Context], [Args]) (Output, [error])
+func (parser *Parser) Field(fn interface{}) *graphql.Field {
+	res, err := parser.TryField(fn)
+	goutils.Assert(err)
+	return res
+}
+
+// same as Field but returns a *ParseError instead of panicking
+func (parser *Parser) TryField(fn interface{}) (res *graphql.Field, err error) {
+	defer goutils.RecoverToErr(&err)
+	parser.mu.Lock()
+	defer parser.mu.Unlock()
+	defer parser.rollbackOnPanic(parser.trackChanges())
+	v := reflect.ValueOf(fn)
+	return parser.parseField(v, rootPath(v.Type()), false), nil
+}
+
+// generate a subscription field from a function of the form func([context.Context], [Args]) (<-chan Output, [error])
+func (parser *Parser) SubscriptionField(fn interface{}) *graphql.Field {
+	res, err := parser.TrySubscriptionField(fn)
+	goutils.Assert(err)
+	return res
+}
+
+// same as SubscriptionField but returns a *ParseError instead of panicking
+func (parser *Parser) TrySubscriptionField(fn interface{}) (res *graphql.Field, err error) {
+	defer goutils.RecoverToErr(&err)
+	parser.mu.Lock()
+	defer parser.mu.Unlock()
+	defer parser.rollbackOnPanic(parser.trackChanges())
+	v := reflect.ValueOf(fn)
+	return parser.parseSubscriptionField(v, rootPath(v.Type())), nil
+}
+
+// when withSource is true the first parameter of fn receives the source of the field
+func (parser *Parser) parseField(fn reflect.Value, path string, withSource bool) *graphql.Field {
+	r := parser.parseResolver(fn, path, withSource)
+	return &graphql.Field{
+		Type: parser.parseResultType(fn.Type().Out(0), path),
+		Args: r.parseArgs(path),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			out, err := r.call(p)
+			if err != nil {
+				return nil, err
+			}
+			return out.Interface(), nil
+		},
+	}
+}
+
+func (parser *Parser) parseSubscriptionField(fn reflect.Value, path string) *graphql.Field {
+	r := parser.parseResolver(fn, path, false)
+	out := fn.Type().Out(0)
+	if out.Kind() != reflect.Chan || out.ChanDir()&reflect.RecvDir == 0 {
+		panic(newParseError(fn.Type(), path, "subscription must return a receivable channel"))
+	}
+	return &graphql.Field{
+		Type: parser.parseResultType(out.Elem(), path),
+		Args: r.parseArgs(path),
+		Subscribe: func(p graphql.ResolveParams) (interface{}, error) {
+			events, err := r.call(p)
+			if err != nil {
+				return nil, err
+			}
+			ctx := p.Context
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			// graphql-go only accepts untyped channels from subscribers
+			res := make(chan interface{})
+			go func() {
+				defer close(res)
+				for {
+					chosen, event, ok := reflect.Select([]reflect.SelectCase{
+						{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+						{Dir: reflect.SelectRecv, Chan: events},
+					})
+					if chosen == 0 || !ok {
+						return
+					}
+					select {
+					case res <- event.Interface():
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+			return res, nil
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source, nil
+		},
+	}
+}
+
+// results follow the nullability policy like untagged struct fields of their Go type
+func (parser *Parser) parseResultType(t reflect.Type, path string) graphql.Type {
+	elemType, _ := parser.unwrapSlice(getType(t))
+	field := reflect.StructField{Type: t}
+	return parser.decorateFieldType(&field, parser.parseOutput(getType(elemType), path))
+}
+
+type resolver struct {
+	parser      *Parser
+	fn          reflect.Value
+	sourceType  reflect.Type
+	withContext bool
+	argsType    reflect.Type
+}
+
+func (parser *Parser) parseResolver(fn reflect.Value, path string, withSource bool) *resolver {
+	t := fn.Type()
+	if t.Kind() != reflect.Func {
+		panic(newParseError(t, path, "resolver must be a function"))
+	}
+	r := resolver{parser: parser, fn: fn}
+	in := 0
+	if withSource {
+		r.sourceType = t.In(in)
+		in++
+	}
+	r.withContext = in < t.NumIn() && t.In(in) == contextType
+	if r.withContext {
+		in++
+	}
+	if in < t.NumIn() {
+		r.argsType = t.In(in)
+		in++
+	}
+	if in < t.NumIn() {
+		panic(newParseError(t, path, "resolver accepts at most a context and an args struct"))
+	}
+	if t.NumOut() < 1 || t.NumOut() > 2 || (t.NumOut() == 2 && t.Out(1) != errorType) {
+		panic(newParseError(t, path, "resolver must return a value and optionally an error"))
+	}
+	return &r
+}
+
+func (r *resolver) parseArgs(path string) graphql.FieldConfigArgument {
+	if r.argsType == nil {
+		return nil
+	}
+	return r.parser.parseArgs(getType(r.argsType), path)
+}
+
+func (r *resolver) call(p graphql.ResolveParams) (reflect.Value, error) {
+	var in []reflect.Value
+	if r.sourceType != nil {
+		source, err := sourceValue(p.Source, r.sourceType)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		in = append(in, source)
+	}
+	if r.withContext {
+		ctx := p.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		in = append(in, reflect.ValueOf(ctx))
+	}
+	if r.argsType != nil {
+		args := reflect.New(r.argsType)
+		if err := r.parser.DecodeArgs(p.Args, args.Interface()); err != nil {
+			return reflect.Value{}, err
+		}
+		in = append(in, args.Elem())
+	}
+	out := r.fn.Call(in)
+	if len(out) == 2 && !out[1].IsNil() {
+		return reflect.Value{}, out[1].Interface().(error)
+	}
+	return out[0], nil
+}
+
+// convert the source of a field to the type expected by its resolver, taking or dereferencing its address as needed
+func sourceValue(source interface{}, t reflect.Type) (reflect.Value, error) {
+	v := reflect.ValueOf(source)
+	if !v.IsValid() {
+		return reflect.Zero(t), nil
+	}
+	for v.Kind() == reflect.Ptr && !v.Type().AssignableTo(t) && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Type().AssignableTo(t) {
+		return v, nil
+	}
+	if reflect.PtrTo(v.Type()).AssignableTo(t) {
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		return ptr, nil
+	}
+	return v, newParseError(t, rootPath(t), "cannot resolve from source of type %T", source)
+}
+
+// struct fields are resolved by index so that renamed and promoted fields resolve regardless of the default resolver
+func resolveStructField(t reflect.Type, index []int) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		v := goutils.UnwrapValue(reflect.ValueOf(p.Source))
+		if !v.IsValid() || v.Type() != t {
+			return graphql.DefaultResolveFn(p)
+		}
+		for _, i := range index {
+			// promoted fields of nil embedded pointers resolve to null
+			if v = goutils.UnwrapValue(v); !v.IsValid() {
+				return nil, nil
+			}
+			v = v.Field(i)
+		}
+		return v.Interface(), nil
+	}
+}
@@ -0,0 +1,71 @@
+package structgraphql_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	structgraphql "github.com/onichandame/struct-graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestField(t *testing.T) {
+	type GreetArgs struct {
+		Name string `graphql:"name"`
+	}
+	type Greeting struct {
+		Message string `graphql:"message"`
+	}
+	query := func(t *testing.T, fields graphql.Fields, request string) *graphql.Result {
+		schema, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{Name: "query", Fields: fields}),
+		})
+		assert.Nil(t, err)
+		return graphql.Do(graphql.Params{Schema: schema, RequestString: request, Context: context.Background()})
+	}
+	t.Run("generates args, type and resolver", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		field := parser.Field(func(ctx context.Context, args GreetArgs) (*Greeting, error) {
+			assert.NotNil(t, ctx)
+			return &Greeting{Message: fmt.Sprintf("hello %v", args.Name)}, nil
+		})
+		assert.NotNil(t, field.Args["name"])
+		assert.Equal(t, parser.ParseOutput(new(Greeting)), field.Type)
+		res := query(t, graphql.Fields{"greet": field}, `{greet(name:"jimmy"){message}}`)
+		assert.Nil(t, res.Errors)
+		assert.Equal(t, map[string]interface{}{"greet": map[string]interface{}{"message": "hello jimmy"}}, res.Data)
+	})
+	t.Run("context and args are optional", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		res := query(t, graphql.Fields{
+			"plain":   parser.Field(func() string { return "plain" }),
+			"args":    parser.Field(func(args *GreetArgs) string { return args.Name }),
+			"context": parser.Field(func(context.Context) ([]int, error) { return []int{1}, nil }),
+		}, `{plain args(name:"args") context}`)
+		assert.Nil(t, res.Errors)
+		assert.Equal(t, map[string]interface{}{"plain": "plain", "args": "args", "context": []interface{}{1}}, res.Data)
+	})
+	t.Run("returns resolver errors", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		res := query(t, graphql.Fields{
+			"fail": parser.Field(func() (*Greeting, error) { return nil, errors.New("failed") }),
+		}, `{fail{message}}`)
+		assert.Len(t, res.Errors, 1)
+		assert.Equal(t, "failed", res.Errors[0].Message)
+	})
+	t.Run("rejects invalid signatures", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		var parseErr *structgraphql.ParseError
+		_, err := parser.TryField("")
+		assert.ErrorAs(t, err, &parseErr)
+		_, err = parser.TryField(func() {})
+		assert.ErrorAs(t, err, &parseErr)
+		_, err = parser.TryField(func() (string, string) { return "", "" })
+		assert.ErrorAs(t, err, &parseErr)
+		_, err = parser.TryField(func(GreetArgs, GreetArgs) string { return "" })
+		assert.ErrorAs(t, err, &parseErr)
+		assert.Panics(t, func() { parser.Field(func(string) string { return "" }) })
+	})
+}
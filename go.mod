@@ -1,6 +1,6 @@
 module github.com/onichandame/struct-graphql
 
-go 1.17
+go 1.18
 
 require (
 	github.com/fatih/structtag v1.2.0
@@ -10,10 +10,13 @@ require (
 require (
 	github.com/onichandame/go-utils v0.0.5
 	github.com/stretchr/testify v1.7.0
+	golang.org/x/tools v0.7.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/mod v0.9.0 // indirect
+	golang.org/x/sys v0.6.0 // indirect
 	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
 )
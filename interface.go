@@ -0,0 +1,123 @@
+package structgraphql
+
+import (
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+	goutils "github.com/onichandame/go-utils"
+)
+
+type parsedInterface struct {
+	goType  reflect.Type
+	gqlType *graphql.Interface
+	fields  graphql.Fields
+	// fields derived from methods are added to the implementing objects as well
+	fromMethods bool
+}
+
+// register the Go interface pointed to by ent, e.g. (*Node)(nil), as a graphql interface declared by every struct implementing it.
+// its fields are those of the struct fieldsFrom if given, otherwise its methods, which become fields of the implementing objects too.
+// an interface must be added before the structs implementing it are parsed
+func (parser *Parser) AddInterface(ent interface{}, fieldsFrom ...interface{}) *graphql.Interface {
+	res, err := parser.TryAddInterface(ent, fieldsFrom...)
+	goutils.Assert(err)
+	return res
+}
+
+// same as AddInterface but returns a *ParseError instead of panicking
+func (parser *Parser) TryAddInterface(ent interface{}, fieldsFrom ...interface{}) (res *graphql.Interface, err error) {
+	defer goutils.RecoverToErr(&err)
+	parser.mu.Lock()
+	defer parser.mu.Unlock()
+	defer parser.rollbackOnPanic(parser.trackChanges())
+	t := getType(ent)
+	return parser.parseInterface(t, rootPath(t), fieldsFrom...), nil
+}
+
+func (parser *Parser) parseInterface(t reflect.Type, path string, fieldsFrom ...interface{}) *graphql.Interface {
+	if t.Kind() != reflect.Interface {
+		panic(newParseError(t, path, "interfaces must be Go interfaces"))
+	}
+	if iface, ok := parser.types[t].(*graphql.Interface); ok {
+		return iface
+	}
+	parsed := parsedInterface{goType: t, fields: make(graphql.Fields), fromMethods: len(fieldsFrom) == 0}
+	// the interface is registered before its fields are loaded so that it can be referenced by them and by the structs implementing it
+	parsed.gqlType = graphql.NewInterface(graphql.InterfaceConfig{
+		Name:        parser.getTypeName(t),
+		Description: getDescription(t),
+		Fields:      graphql.FieldsThunk(func() graphql.Fields { return parsed.fields }),
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			parser.mu.RLock()
+			defer parser.mu.RUnlock()
+			object, _ := parser.types[getType(reflect.TypeOf(p.Value))].(*graphql.Object)
+			return object
+		},
+	})
+	parser.registerName(t, parsed.gqlType, path)
+	parser.setType(t, parsed.gqlType)
+	parser.interfaces = append(parser.interfaces, &parsed)
+	parser.recordChange(func() { parser.interfaces = parser.interfaces[:len(parser.interfaces)-1] })
+	if parsed.fromMethods {
+		for i := 0; i < t.NumMethod(); i++ {
+			method := t.Method(i)
+			if method.PkgPath != "" {
+				continue
+			}
+			parsed.fields[parser.getMethodName(method.Name)] = parser.parseField(interfaceMethodFunc(t, method), path+"."+method.Name+"()", true)
+		}
+	} else {
+		parser.loadOutputFields(getType(fieldsFrom[0]), path, parsed.fields)
+	}
+	return parsed.gqlType
+}
+
+// the interfaces implemented by struct t
+func (parser *Parser) getInterfaces(t reflect.Type) []*parsedInterface {
+	var res []*parsedInterface
+	for _, iface := range parser.interfaces {
+		if reflect.PtrTo(t).Implements(iface.goType) {
+			res = append(res, iface)
+		}
+	}
+	return res
+}
+
+func (parser *Parser) getInterfaceTypes(interfaces []*parsedInterface) []*graphql.Interface {
+	var res []*graphql.Interface
+	for _, iface := range interfaces {
+		res = append(res, iface.gqlType)
+	}
+	return res
+}
+
+// merged lazily as the fields of an interface may still be loading when an object implementing it is parsed
+func withInterfaceFields(fields graphql.Fields, interfaces []*parsedInterface) graphql.Fields {
+	res := make(graphql.Fields)
+	for _, iface := range interfaces {
+		if iface.fromMethods {
+			for name, field := range iface.fields {
+				res[name] = field
+			}
+		}
+	}
+	for name, field := range fields {
+		res[name] = field
+	}
+	return res
+}
+
+// a function calling method on a receiver of interface type t, as interface methods have no function of their own
+func interfaceMethodFunc(t reflect.Type, method reflect.Method) reflect.Value {
+	in := []reflect.Type{t}
+	for i := 0; i < method.Type.NumIn(); i++ {
+		in = append(in, method.Type.In(i))
+	}
+	var out []reflect.Type
+	for i := 0; i < method.Type.NumOut(); i++ {
+		out = append(out, method.Type.Out(i))
+	}
+	return reflect.MakeFunc(reflect.FuncOf(in, out, method.Type.IsVariadic()), func(args []reflect.Value) []reflect.Value {
+		return args[0].MethodByName(method.Name).Call(args[1:])
+	})
+}
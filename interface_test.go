@@ -0,0 +1,94 @@
+package structgraphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	structgraphql "github.com/onichandame/struct-graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+type Node interface{ GetID() ID }
+
+type Entity struct {
+	ID ID `graphql:"id"`
+}
+
+type Book struct {
+	Entity
+	Title string `graphql:"title"`
+}
+
+func (b *Book) GetID() ID { return b.ID }
+
+type Author struct {
+	Entity
+	Name  string  `graphql:"name"`
+	Books []*Book `graphql:"books"`
+}
+
+func (a *Author) GetID() ID { return a.ID }
+
+type NodeResolver struct{ nodes []Node }
+
+func (r *NodeResolver) Nodes() []Node { return r.nodes }
+
+func TestInterface(t *testing.T) {
+	nodes := &NodeResolver{nodes: []Node{&Book{Entity: Entity{ID: 1}, Title: "Dune"}, &Author{Entity: Entity{ID: 2}, Name: "Herbert"}}}
+	t.Run("derives fields from methods", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		iface := parser.AddInterface((*Node)(nil))
+		assert.Equal(t, "Node", iface.Name())
+		assert.Equal(t, []string{"GetID"}, sortedKeys(iface.Fields()))
+		book := parser.ParseOutput(new(Book)).(*graphql.Object)
+		assert.Equal(t, []*graphql.Interface{iface}, book.Interfaces())
+		assert.Equal(t, []string{"GetID", "id", "title"}, sortedKeys(book.Fields()))
+		parser.ParseOutput(new(Author))
+		schema, err := structgraphql.NewSchemaBuilder(parser).Queries(nodes).Build()
+		assert.Nil(t, err)
+		res := graphql.Do(graphql.Params{Schema: schema, RequestString: `{Nodes{GetID ... on Book{title} ... on Author{name}}}`})
+		assert.Nil(t, res.Errors)
+		assert.Equal(t, map[string]interface{}{"Nodes": []interface{}{
+			map[string]interface{}{"GetID": "1", "title": "Dune"},
+			map[string]interface{}{"GetID": "2", "name": "Herbert"},
+		}}, res.Data)
+	})
+	t.Run("derives fields from an embedded struct", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		iface := parser.AddInterface((*Node)(nil), Entity{})
+		assert.Equal(t, []string{"id"}, sortedKeys(iface.Fields()))
+		author := parser.ParseOutput(new(Author)).(*graphql.Object)
+		assert.Equal(t, []*graphql.Interface{iface}, author.Interfaces())
+		assert.Equal(t, []string{"books", "id", "name"}, sortedKeys(author.Fields()))
+		schema, err := structgraphql.NewSchemaBuilder(parser).Queries(nodes).Build()
+		assert.Nil(t, err)
+		res := graphql.Do(graphql.Params{Schema: schema, RequestString: `{Nodes{id __typename}}`})
+		assert.Nil(t, res.Errors)
+		assert.Equal(t, map[string]interface{}{"Nodes": []interface{}{
+			map[string]interface{}{"id": "1", "__typename": "Book"},
+			map[string]interface{}{"id": "2", "__typename": "Author"},
+		}}, res.Data)
+	})
+	t.Run("resolves the registered object of the runtime type", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		iface := parser.AddInterface((*Node)(nil))
+		book := parser.ParseOutput(new(Book))
+		assert.Equal(t, book, iface.ResolveType(graphql.ResolveTypeParams{Value: &Book{}}))
+		assert.Equal(t, book, iface.ResolveType(graphql.ResolveTypeParams{Value: Book{}}))
+		assert.Nil(t, iface.ResolveType(graphql.ResolveTypeParams{Value: &Author{}}))
+	})
+	t.Run("rejects non-interfaces", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		_, err := parser.TryAddInterface(Entity{})
+		assert.Error(t, err)
+		assert.Panics(t, func() { parser.AddInterface(Entity{}) })
+	})
+	t.Run("cannot be an input", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		parser.AddInterface((*Node)(nil))
+		_, err := parser.TryParseArgs(struct {
+			Node Node `graphql:"node"`
+		}{})
+		assert.Contains(t, err.Error(), "the interface Node cannot be an input")
+	})
+}
@@ -0,0 +1,150 @@
+// Code generated by struct-graphql. DO NOT EDIT.
+
+package testclient
+
+import (
+	"context"
+	structgraphql "github.com/onichandame/struct-graphql"
+	"github.com/onichandame/struct-graphql/internal/testclient/models"
+	"time"
+)
+
+// ActivityDocument is sent by Activity
+const ActivityDocument = `query Activity($withAuthors: Boolean!) {
+  activity {
+    __typename
+    ... on Ticket {
+      ...TicketFields
+    }
+    ... on Comment {
+      body
+      author @include(if: $withAuthors) {
+        name
+      }
+    }
+  }
+}
+
+fragment TicketFields on Ticket {
+  id
+  title
+  status
+  labels
+  created
+}`
+
+type ActivityVariables struct {
+	WithAuthors bool `json:"withAuthors"`
+}
+
+type ActivityResult struct {
+	Activity []*ActivityResultActivity `json:"activity"`
+}
+
+type ActivityResultActivity struct {
+	Typename string                        `json:"__typename"`
+	Id       *string                       `json:"id"`
+	Title    *string                       `json:"title"`
+	Status   *Status                       `json:"status"`
+	Labels   []*models.Label               `json:"labels"`
+	Created  *time.Time                    `json:"created"`
+	Body     *string                       `json:"body"`
+	Author   *ActivityResultActivityAuthor `json:"author"`
+}
+
+type ActivityResultActivityAuthor struct {
+	Name string `json:"name"`
+}
+
+// send Activity with client. the data the server could resolve is returned along with a *structgraphql.ResponseError
+func Activity(ctx context.Context, client *structgraphql.Client, variables ActivityVariables) (*ActivityResult, error) {
+	var res ActivityResult
+	err := client.Do(ctx, ActivityDocument, variables, &res)
+	return &res, err
+}
+
+// CreateTicketDocument is sent by CreateTicket
+const CreateTicketDocument = `mutation CreateTicket($ticket: NewTicket!) {
+  created: createTicket(ticket: $ticket) {
+    id
+    status
+  }
+}`
+
+type CreateTicketVariables struct {
+	Ticket NewTicket `json:"ticket"`
+}
+
+type CreateTicketResult struct {
+	Created *CreateTicketResultCreated `json:"created"`
+}
+
+type CreateTicketResultCreated struct {
+	Id     string `json:"id"`
+	Status Status `json:"status"`
+}
+
+// send CreateTicket with client. the data the server could resolve is returned along with a *structgraphql.ResponseError
+func CreateTicket(ctx context.Context, client *structgraphql.Client, variables CreateTicketVariables) (*CreateTicketResult, error) {
+	var res CreateTicketResult
+	err := client.Do(ctx, CreateTicketDocument, variables, &res)
+	return &res, err
+}
+
+// ListTicketsDocument is sent by ListTickets
+const ListTicketsDocument = `query ListTickets($filter: TicketFilter) {
+  tickets(filter: $filter) {
+    ...TicketFields
+    assignee {
+      name
+    }
+  }
+}
+
+fragment TicketFields on Ticket {
+  id
+  title
+  status
+  labels
+  created
+}`
+
+type ListTicketsVariables struct {
+	Filter *models.TicketFilter `json:"filter,omitempty"`
+}
+
+type ListTicketsResult struct {
+	Tickets []*ListTicketsResultTickets `json:"tickets"`
+}
+
+type ListTicketsResultTickets struct {
+	Id       string                            `json:"id"`
+	Title    string                            `json:"title"`
+	Status   Status                            `json:"status"`
+	Labels   []*models.Label                   `json:"labels"`
+	Created  time.Time                         `json:"created"`
+	Assignee *ListTicketsResultTicketsAssignee `json:"assignee"`
+}
+
+type ListTicketsResultTicketsAssignee struct {
+	Name string `json:"name"`
+}
+
+// send ListTickets with client. the data the server could resolve is returned along with a *structgraphql.ResponseError
+func ListTickets(ctx context.Context, client *structgraphql.Client, variables ListTicketsVariables) (*ListTicketsResult, error) {
+	var res ListTicketsResult
+	err := client.Do(ctx, ListTicketsDocument, variables, &res)
+	return &res, err
+}
+
+type Status string
+
+const (
+	StatusCLOSED Status = "CLOSED"
+	StatusOPEN   Status = "OPEN"
+)
+
+type NewTicket struct {
+	Status *Status `json:"status,omitempty"`
+	Title  string  `json:"title"`
+}
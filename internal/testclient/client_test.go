@@ -0,0 +1,100 @@
+package testclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	structgraphql "github.com/onichandame/struct-graphql"
+	"github.com/onichandame/struct-graphql/internal/testclient"
+	"github.com/onichandame/struct-graphql/internal/testclient/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newServer(t *testing.T, schema graphql.Schema) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		res := graphql.Do(graphql.Params{Schema: schema, RequestString: body.Query, VariableValues: body.Variables, Context: r.Context()})
+		assert.Nil(t, json.NewEncoder(w).Encode(res))
+	}))
+}
+
+func TestClient(t *testing.T) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	resolver := &models.Resolver{
+		Tickets: []*models.Ticket{
+			{ID: 1, Title: "fix login", Status: models.StatusOpen, Labels: []models.Label{"bug"}, Assignee: &models.User{Name: "ann"}, Created: created},
+			{ID: 2, Title: "write docs", Status: models.StatusClosed, Created: created},
+		},
+		Comments: []*models.Comment{{Body: "on it", Author: models.User{Name: "bob"}}},
+	}
+	parser := models.NewParser()
+	schema, err := models.NewSchema(parser, resolver)
+	assert.Nil(t, err)
+	server := newServer(t, schema)
+	defer server.Close()
+	client := structgraphql.NewClient(server.URL, structgraphql.WithTransport(server.Client()))
+	ctx := context.Background()
+	t.Run("is up to date", func(t *testing.T) {
+		operations, err := os.ReadFile("operations.graphql")
+		assert.Nil(t, err)
+		src, err := parser.GenerateClient(schema, "github.com/onichandame/struct-graphql/internal/testclient", "testclient", string(operations))
+		assert.Nil(t, err)
+		committed, err := os.ReadFile("client_gen.go")
+		assert.Nil(t, err)
+		assert.Equal(t, string(committed), string(src))
+	})
+	t.Run("sends reused variables", func(t *testing.T) {
+		res, err := testclient.ListTickets(ctx, client, testclient.ListTicketsVariables{Filter: &models.TicketFilter{Title: "fix"}})
+		assert.Nil(t, err)
+		assert.Len(t, res.Tickets, 1)
+		ticket := res.Tickets[0]
+		assert.Equal(t, "1", ticket.Id)
+		assert.Equal(t, testclient.StatusOPEN, ticket.Status)
+		assert.Equal(t, "bug", string(*ticket.Labels[0]))
+		assert.Equal(t, "ann", ticket.Assignee.Name)
+		assert.True(t, created.Equal(ticket.Created))
+		res, err = testclient.ListTickets(ctx, client, testclient.ListTicketsVariables{})
+		assert.Nil(t, err)
+		assert.Len(t, res.Tickets, 2)
+		assert.Nil(t, res.Tickets[1].Assignee)
+	})
+	t.Run("resolves abstract types", func(t *testing.T) {
+		res, err := testclient.Activity(ctx, client, testclient.ActivityVariables{WithAuthors: true})
+		assert.Nil(t, err)
+		assert.Len(t, res.Activity, 3)
+		assert.Equal(t, "Ticket", res.Activity[0].Typename)
+		assert.Equal(t, "fix login", *res.Activity[0].Title)
+		assert.Nil(t, res.Activity[0].Body)
+		assert.Equal(t, "Comment", res.Activity[1].Typename)
+		assert.Equal(t, "on it", *res.Activity[1].Body)
+		assert.Equal(t, "bob", res.Activity[1].Author.Name)
+		res, err = testclient.Activity(ctx, client, testclient.ActivityVariables{WithAuthors: false})
+		assert.Nil(t, err)
+		assert.Nil(t, res.Activity[1].Author)
+	})
+	t.Run("sends mirrored variables", func(t *testing.T) {
+		closed := testclient.StatusCLOSED
+		res, err := testclient.CreateTicket(ctx, client, testclient.CreateTicketVariables{Ticket: testclient.NewTicket{Title: "release", Status: &closed}})
+		assert.Nil(t, err)
+		assert.Equal(t, "3", res.Created.Id)
+		assert.Equal(t, testclient.StatusCLOSED, res.Created.Status)
+		assert.Equal(t, models.StatusClosed, resolver.Tickets[2].Status)
+	})
+	t.Run("returns the errors of the server", func(t *testing.T) {
+		res, err := testclient.CreateTicket(ctx, client, testclient.CreateTicketVariables{Ticket: testclient.NewTicket{}})
+		var resErr *structgraphql.ResponseError
+		assert.ErrorAs(t, err, &resErr)
+		assert.Equal(t, "title must not be empty", resErr.Errors[0].Message)
+		assert.Nil(t, res.Created)
+	})
+}
@@ -0,0 +1,4 @@
+// Package testclient is a client generated from operations.graphql for the server of package models
+package testclient
+
+//go:generate go run gen.go
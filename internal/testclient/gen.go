@@ -0,0 +1,29 @@
+//go:build ignore
+
+// writes client_gen.go from the operations of operations.graphql
+package main
+
+import (
+	"os"
+
+	"github.com/onichandame/struct-graphql/internal/testclient/models"
+)
+
+func main() {
+	parser := models.NewParser()
+	schema, err := models.NewSchema(parser, &models.Resolver{})
+	if err != nil {
+		panic(err)
+	}
+	operations, err := os.ReadFile("operations.graphql")
+	if err != nil {
+		panic(err)
+	}
+	src, err := parser.GenerateClient(schema, "github.com/onichandame/struct-graphql/internal/testclient", "testclient", string(operations))
+	if err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile("client_gen.go", src, 0644); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,131 @@
+// Package models declares the server the generated client of testclient is tested against
+package models
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	structgraphql "github.com/onichandame/struct-graphql"
+)
+
+type TicketID int
+
+func (TicketID) IsID() bool { return true }
+
+type Label string
+
+type Status int
+
+const (
+	StatusOpen Status = iota
+	StatusClosed
+)
+
+func (Status) GetValues() map[string]interface{} {
+	return map[string]interface{}{"OPEN": StatusOpen, "CLOSED": StatusClosed}
+}
+
+type User struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+}
+
+type Ticket struct {
+	ID       TicketID  `json:"id"`
+	Title    string    `json:"title"`
+	Status   Status    `json:"status"`
+	Labels   []Label   `json:"labels"`
+	Assignee *User     `json:"assignee,omitempty"`
+	Created  time.Time `json:"created"`
+}
+
+func (*Ticket) isActivity() {}
+
+func (Ticket) GetDescription() string { return "A unit of work" }
+
+type Comment struct {
+	Body   string `json:"body"`
+	Author User   `json:"author"`
+}
+
+func (*Comment) isActivity() {}
+
+type Activity interface{ isActivity() }
+
+// encodes to JSON as the server expects, so clients reuse it
+type TicketFilter struct {
+	Title  string  `json:"title,omitempty"`
+	Labels []Label `json:"labels,omitempty"`
+	Limit  int     `json:"limit,omitempty"`
+}
+
+// its zero value leaves out the required title and sends null for the required ids, so clients mirror it
+type TicketSearch struct {
+	Title string `graphql:"title" json:"title,omitempty"`
+	IDs   []int  `graphql:"ids" json:"ids"`
+}
+
+// its enum encodes to JSON as a number, so clients mirror it
+type NewTicket struct {
+	Title  string `json:"title"`
+	Status Status `json:"status,omitempty"`
+}
+
+type Resolver struct {
+	Tickets  []*Ticket
+	Comments []*Comment
+}
+
+type Queries struct{ *Resolver }
+
+func (q Queries) Tickets(ctx context.Context, args struct {
+	Filter *TicketFilter `json:"filter,omitempty"`
+}) []*Ticket {
+	var res []*Ticket
+	for _, ticket := range q.Resolver.Tickets {
+		if args.Filter == nil || strings.HasPrefix(ticket.Title, args.Filter.Title) {
+			res = append(res, ticket)
+		}
+	}
+	if args.Filter != nil && args.Filter.Limit > 0 && len(res) > args.Filter.Limit {
+		res = res[:args.Filter.Limit]
+	}
+	return res
+}
+
+func (q Queries) Activity(ctx context.Context) []Activity {
+	var res []Activity
+	for i, ticket := range q.Resolver.Tickets {
+		res = append(res, ticket)
+		if i < len(q.Resolver.Comments) {
+			res = append(res, q.Resolver.Comments[i])
+		}
+	}
+	return res
+}
+
+type Mutations struct{ *Resolver }
+
+func (m Mutations) CreateTicket(ctx context.Context, args struct {
+	Ticket NewTicket `json:"ticket"`
+}) (*Ticket, error) {
+	if args.Ticket.Title == "" {
+		return nil, errors.New("title must not be empty")
+	}
+	ticket := &Ticket{ID: TicketID(len(m.Resolver.Tickets) + 1), Title: args.Ticket.Title, Status: args.Ticket.Status, Labels: []Label{}}
+	m.Resolver.Tickets = append(m.Resolver.Tickets, ticket)
+	return ticket, nil
+}
+
+func NewParser() *structgraphql.Parser {
+	parser := structgraphql.NewParser(structgraphql.WithJSONOmitEmptyNullable(), structgraphql.WithFieldName(structgraphql.CamelCase))
+	parser.AddUnion((*Activity)(nil), Ticket{}, Comment{})
+	return parser
+}
+
+func NewSchema(parser *structgraphql.Parser, resolver *Resolver) (graphql.Schema, error) {
+	return structgraphql.NewSchemaBuilder(parser).Queries(Queries{resolver}).Mutations(Mutations{resolver}).Build()
+}
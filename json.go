@@ -0,0 +1,44 @@
+package structgraphql
+
+import (
+	"reflect"
+
+	"github.com/fatih/structtag"
+)
+
+// fall back to json tags for the names and omission of fields without a graphql tag
+func WithJSONTags() Option {
+	return func(parser *Parser) { parser.jsonTags = true }
+}
+
+// fall back to json tags and make fields named by them non-null unless they have the omitempty option
+func WithJSONOmitEmptyNullable() Option {
+	return func(parser *Parser) {
+		parser.jsonTags = true
+		parser.jsonNullability = true
+	}
+}
+
+// the json tag of a field, nil if json tags are not enabled or the field has a graphql tag
+func (parser *Parser) getJSONTag(field *reflect.StructField) *structtag.Tag {
+	if !parser.jsonTags {
+		return nil
+	}
+	tags, _ := structtag.Parse(string(field.Tag))
+	if tags == nil {
+		return nil
+	}
+	if tag, _ := tags.Get(TAG_PREFIX); tag != nil {
+		return nil
+	}
+	tag, _ := tags.Get(TAG_JSON)
+	return tag
+}
+
+func (parser *Parser) isOmitted(field *reflect.StructField, sideTag string) bool {
+	if isOmitted(field, sideTag) {
+		return true
+	}
+	tag := parser.getJSONTag(field)
+	return tag != nil && tag.Name == TAG_OMIT && len(tag.Options) == 0
+}
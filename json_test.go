@@ -0,0 +1,54 @@
+package structgraphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	structgraphql "github.com/onichandame/struct-graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+type Article struct {
+	ID       int      `json:"id"`
+	Title    string   `json:"title,omitempty"`
+	Body     string   `graphql:"content,nullable" json:"body"`
+	Secret   string   `json:"-"`
+	Internal string   `graphql:"internal" json:"-"`
+	Tags     []string `json:",omitempty"`
+	Plain    string
+}
+
+func TestJSONTags(t *testing.T) {
+	t.Run("ignored by default", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		output := parser.ParseOutput(new(Article)).(*graphql.Object)
+		assert.Equal(t, []string{"ID", "Plain", "Secret", "Tags", "Title", "content", "internal"}, sortedKeys(output.Fields()))
+	})
+	t.Run("names and omission", func(t *testing.T) {
+		parser := structgraphql.NewParser(structgraphql.WithJSONTags(), structgraphql.WithInputSuffix("Input"))
+		output := parser.ParseOutput(new(Article)).(*graphql.Object)
+		assert.Equal(t, []string{"Plain", "Tags", "content", "id", "internal", "title"}, sortedKeys(output.Fields()))
+		assert.Equal(t, graphql.Int, output.Fields()["id"].Type)
+		input := parser.ParseInput(new(Article)).(*graphql.InputObject)
+		assert.Equal(t, []string{"Plain", "Tags", "content", "id", "internal", "title"}, sortedKeys(input.Fields()))
+		var article Article
+		assert.Nil(t, parser.DecodeArgs(map[string]interface{}{"id": 1, "title": "title", "Secret": "secret"}, &article))
+		assert.Equal(t, Article{ID: 1, Title: "title"}, article)
+		schema, err := structgraphql.NewSchemaBuilder(parser).Query("article", func() Article {
+			return Article{ID: 1, Title: "title", Body: "body"}
+		}).Build()
+		assert.Nil(t, err)
+		res := graphql.Do(graphql.Params{Schema: schema, RequestString: `{article{id title content}}`})
+		assert.Nil(t, res.Errors)
+		assert.Equal(t, map[string]interface{}{"article": map[string]interface{}{"id": 1, "title": "title", "content": "body"}}, res.Data)
+	})
+	t.Run("omitempty as nullable", func(t *testing.T) {
+		parser := structgraphql.NewParser(structgraphql.WithJSONOmitEmptyNullable())
+		output := parser.ParseOutput(new(Article)).(*graphql.Object)
+		assert.IsType(t, new(graphql.NonNull), output.Fields()["id"].Type)
+		assert.Equal(t, graphql.String, output.Fields()["title"].Type)
+		assert.Equal(t, graphql.String, output.Fields()["content"].Type)
+		assert.IsType(t, new(graphql.List), output.Fields()["Tags"].Type)
+		assert.Equal(t, graphql.String, output.Fields()["Plain"].Type)
+	})
+}
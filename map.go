@@ -0,0 +1,145 @@
+package structgraphql
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/fatih/structtag"
+	"github.com/graphql-go/graphql"
+)
+
+type mapEntry struct {
+	Key   interface{}
+	Value interface{}
+}
+
+// maps are parsed as lists of key/value entries instead of the JSON scalar when their fields have the entries option
+func hasEntries(field *reflect.StructField) bool {
+	if tags, _ := structtag.Parse(string(field.Tag)); tags != nil {
+		if tag, _ := tags.Get(TAG_PREFIX); tag != nil {
+			return tag.HasOption(TAG_ENTRIES)
+		}
+	}
+	return false
+}
+
+// the list of entries of map t, e.g. [LabelsEntry!] for type Labels map[string]string
+func (parser *Parser) parseOutputEntries(t reflect.Type, sliceDims int, path string) graphql.Type {
+	if t.Kind() != reflect.Map || sliceDims > 0 {
+		panic(newParseError(t, path, "entries are only supported for map fields"))
+	}
+	key := parser.parseOutput(t.Key(), path+"{key}")
+	value := parser.parseOutput(t.Elem(), path+"{value}")
+	name := parser.getEntryName(t, key, value, path)
+	if _, ok := parser.entries[t]; !ok {
+		entry := graphql.NewObject(graphql.ObjectConfig{
+			Name: name,
+			Fields: graphql.Fields{
+				"key":   &graphql.Field{Type: graphql.NewNonNull(key), Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(mapEntry).Key, nil }},
+				"value": &graphql.Field{Type: parser.decorateEntryValue(t.Elem(), value), Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(mapEntry).Value, nil }},
+			},
+		})
+		parser.registerName(t, entry, path)
+		parser.entries[t] = entry
+		parser.recordChange(func() { delete(parser.entries, t) })
+	}
+	return graphql.NewList(graphql.NewNonNull(parser.entries[t]))
+}
+
+// the list of entries of map t as input, e.g. [LabelsEntryInput!]
+func (parser *Parser) parseInputEntries(t reflect.Type, sliceDims int, path string) graphql.Input {
+	if t.Kind() != reflect.Map || sliceDims > 0 {
+		panic(newParseError(t, path, "entries are only supported for map fields"))
+	}
+	key := parser.parseInput(t.Key(), path+"{key}")
+	value := parser.parseInput(t.Elem(), path+"{value}")
+	if _, ok := parser.inputEntries[t]; !ok {
+		name := parser.getEntryName(t, key, value, path)
+		// generated entries have no Go type to implement GetInputName, so they are always named apart from their objects
+		if parser.inputNamer != nil {
+			name = parser.inputNamer(t, name)
+		} else {
+			name += "Input"
+		}
+		entry := graphql.NewInputObject(graphql.InputObjectConfig{
+			Name: name,
+			Fields: graphql.InputObjectConfigFieldMap{
+				"key":   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(key)},
+				"value": &graphql.InputObjectFieldConfig{Type: parser.decorateEntryValue(t.Elem(), value)},
+			},
+		})
+		parser.registerName(t, entry, path)
+		parser.inputEntries[t] = entry
+		parser.recordChange(func() { delete(parser.inputEntries, t) })
+	}
+	return graphql.NewList(graphql.NewNonNull(parser.inputEntries[t]))
+}
+
+// named maps give their name to their entries, other entries are named after their keys and values, e.g. StringIntListEntry for map[string][]int
+func (parser *Parser) getEntryName(t reflect.Type, key, value graphql.Type, path string) string {
+	if _, ok := graphql.GetNullable(key).(*graphql.List); ok {
+		panic(newParseError(t, path, "keys of entries must not be lists"))
+	}
+	if t.Name() != "" {
+		return parser.getTypeName(t) + "Entry"
+	}
+	name := upperFirst(key.Name())
+	var suffix string
+	for list, ok := value.(*graphql.List); ok; list, ok = value.(*graphql.List) {
+		value = list.OfType
+		suffix += "List"
+	}
+	return name + upperFirst(value.Name()) + suffix + "Entry"
+}
+
+// values of entries are nullable as untagged fields are
+func (parser *Parser) decorateEntryValue(t reflect.Type, value graphql.Type) graphql.Type {
+	field := reflect.StructField{Name: "Value", Type: t}
+	_, sliceDims := parser.unwrapSlice(t)
+	for i := 0; i < sliceDims; i++ {
+		value = value.(*graphql.List).OfType
+	}
+	return parser.decorateFieldType(&field, value)
+}
+
+// resolve a map as its entries sorted by key
+func resolveEntries(resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		res, err := resolve(p)
+		if err != nil || res == nil {
+			return res, err
+		}
+		v := reflect.ValueOf(res)
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return nil, nil
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Map || v.IsNil() {
+			return nil, nil
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return lessKey(keys[i], keys[j]) })
+		entries := make([]mapEntry, 0, len(keys))
+		for _, key := range keys {
+			entries = append(entries, mapEntry{Key: key.Interface(), Value: v.MapIndex(key).Interface()})
+		}
+		return entries, nil
+	}
+}
+
+func lessKey(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	case reflect.String:
+		return a.String() < b.String()
+	}
+	return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+}
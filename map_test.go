@@ -0,0 +1,65 @@
+package structgraphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	structgraphql "github.com/onichandame/struct-graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+type Labels map[string]string
+
+type Resource struct {
+	Name     string                 `graphql:"name"`
+	Labels   Labels                 `graphql:"labels,entries"`
+	Scores   map[string][]int       `graphql:"scores,entries"`
+	Metadata map[string]interface{} `graphql:"metadata,nullable"`
+}
+
+func TestMap(t *testing.T) {
+	t.Run("parses maps as JSON by default", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		resource := parser.ParseOutput(Resource{}).(*graphql.Object)
+		assert.Equal(t, structgraphql.JSONScalar, resource.Fields()["metadata"].Type)
+		assert.Equal(t, structgraphql.JSONScalar, parser.ParseOutput(Labels{}))
+		assert.Equal(t, structgraphql.JSONScalar, parser.ParseInput(map[int]bool{}))
+	})
+	t.Run("parses maps as entries by tag", func(t *testing.T) {
+		parser := structgraphql.NewParser(structgraphql.WithInputSuffix("Input"))
+		resource := parser.ParseOutput(Resource{}).(*graphql.Object)
+		assert.Equal(t, "[LabelsEntry!]!", resource.Fields()["labels"].Type.String())
+		assert.Equal(t, "[StringIntListEntry!]!", resource.Fields()["scores"].Type.String())
+		entry := resource.Fields()["scores"].Type.(*graphql.NonNull).OfType.(*graphql.List).OfType.(*graphql.NonNull).OfType.(*graphql.Object)
+		assert.Equal(t, "String!", entry.Fields()["key"].Type.String())
+		assert.Equal(t, "[Int]", entry.Fields()["value"].Type.String())
+		input := parser.ParseInput(Resource{}).(*graphql.InputObject)
+		assert.Equal(t, "[LabelsEntryInput!]!", input.Fields()["labels"].Type.String())
+		_, err := parser.TryParseOutput(struct {
+			Names []string `graphql:"names,entries"`
+		}{})
+		assert.Error(t, err)
+	})
+	t.Run("resolves and decodes maps", func(t *testing.T) {
+		parser := structgraphql.NewParser(structgraphql.WithInputSuffix("Input"))
+		type Args struct {
+			Resource Resource `graphql:"resource"`
+		}
+		schema, err := structgraphql.NewSchemaBuilder(parser).
+			Query("echo", func(args Args) *Resource { return &args.Resource }).
+			Build()
+		assert.Nil(t, err)
+		res := graphql.Do(graphql.Params{Schema: schema, RequestString: `{echo(resource:{
+			name:"web",
+			labels:[{key:"tier",value:"front"},{key:"app",value:"shop"}],
+			scores:[{key:"a",value:[1,2]}],
+			metadata:{replicas:3,tags:["x"]}
+		}){labels{key value} scores{key value} metadata}}`})
+		assert.Nil(t, res.Errors)
+		assert.Equal(t, map[string]interface{}{"echo": map[string]interface{}{
+			"labels":   []interface{}{map[string]interface{}{"key": "app", "value": "shop"}, map[string]interface{}{"key": "tier", "value": "front"}},
+			"scores":   []interface{}{map[string]interface{}{"key": "a", "value": []interface{}{1, 2}}},
+			"metadata": map[string]interface{}{"replicas": 3, "tags": []interface{}{"x"}},
+		}}, res.Data)
+	})
+}
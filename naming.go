@@ -0,0 +1,188 @@
+package structgraphql
+
+import (
+	"path"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/graphql-go/graphql"
+	goutils "github.com/onichandame/go-utils"
+)
+
+type Option func(*Parser)
+
+// name input objects with fn, which receives the Go type and the name its output object would have
+func WithInputName(fn func(t reflect.Type, name string) string) Option {
+	return func(parser *Parser) { parser.inputNamer = fn }
+}
+
+// name input objects after their output objects followed by suffix, e.g. User and UserInput
+func WithInputSuffix(suffix string) Option {
+	return WithInputName(func(t reflect.Type, name string) string { return name + suffix })
+}
+
+func (parser *Parser) getInputName(t reflect.Type) string {
+	if named, ok := reflect.New(t).Interface().(InputNamed); ok {
+		return named.GetInputName()
+	}
+	name := parser.getTypeName(t)
+	if parser.inputNamer != nil {
+		name = parser.inputNamer(t, name)
+	}
+	return name
+}
+
+type namedType struct {
+	goType  reflect.Type
+	gqlType graphql.Type
+}
+
+// a graphql name can only be taken by a single graphql type, which may be shared by several Go types
+func (parser *Parser) registerName(t reflect.Type, gqlType graphql.Type, path string) {
+	name := gqlType.Name()
+	if named, ok := parser.names[name]; ok && named.gqlType != gqlType {
+		panic(newParseError(t, path, "name %v is already taken by %v generated from %v", name, describeType(named.gqlType), named.goType))
+	}
+	if prev, ok := parser.names[name]; ok {
+		parser.recordChange(func() { parser.names[name] = prev })
+	} else {
+		parser.recordChange(func() { delete(parser.names, name) })
+	}
+	parser.names[name] = namedType{goType: t, gqlType: gqlType}
+}
+
+func describeType(t graphql.Type) string {
+	switch t.(type) {
+	case *graphql.Object:
+		return "the object"
+	case *graphql.InputObject:
+		return "the input object"
+	case *graphql.Enum:
+		return "the enum"
+	case *graphql.Interface:
+		return "the interface"
+	case *graphql.Union:
+		return "the union"
+	default:
+		return "the scalar"
+	}
+}
+
+// name types with fn, which receives the Go type and its name
+func WithTypeName(fn func(t reflect.Type, name string) string) Option {
+	return func(parser *Parser) { parser.typeNamer = fn }
+}
+
+// name fields and resolver methods that are not named by tags with fn, e.g. WithFieldName(CamelCase)
+func WithFieldName(fn func(name string) string) Option {
+	return func(parser *Parser) { parser.fieldNamer = fn }
+}
+
+// name the values passed to AddEnumByValues with fn, e.g. WithEnumValueName(ScreamingSnakeCase)
+func WithEnumValueName(fn func(name string) string) Option {
+	return func(parser *Parser) { parser.enumValueNamer = fn }
+}
+
+func (parser *Parser) getTypeName(t reflect.Type) string {
+	t = goutils.UnwrapType(t)
+	if _, ok := reflect.New(t).Interface().(Named); ok || parser.typeNamer == nil {
+		return getName(t)
+	}
+	return parser.typeNamer(t, t.Name())
+}
+
+func (parser *Parser) getFieldName(field *reflect.StructField) string {
+	if name := getTagName(field); name != "" {
+		return name
+	}
+	if tag := parser.getJSONTag(field); tag != nil && tag.Name != "" {
+		return tag.Name
+	}
+	return parser.getMethodName(field.Name)
+}
+
+func (parser *Parser) getMethodName(name string) string {
+	if parser.fieldNamer != nil {
+		name = parser.fieldNamer(name)
+	}
+	return name
+}
+
+func (parser *Parser) getEnumValueName(name string) string {
+	if parser.enumValueNamer != nil {
+		name = parser.enumValueNamer(name)
+	}
+	return name
+}
+
+// FullName becomes fullName and UserID becomes userID
+func CamelCase(name string) string {
+	words := splitWords(name)
+	for i, word := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(word)
+		} else {
+			words[i] = upperFirst(word)
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// StatusActive becomes STATUS_ACTIVE
+func ScreamingSnakeCase(name string) string {
+	return strings.ToUpper(strings.Join(splitWords(name), "_"))
+}
+
+// prefix the name of a type with its package, e.g. models.User becomes ModelsUser. the type arguments of generic types are qualified the same way
+func PackageQualified(t reflect.Type, name string) string {
+	var words []string
+	if t.PkgPath() != "" {
+		words = append(words, splitWords(path.Base(t.PkgPath()))...)
+	}
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return strings.ContainsRune("[], *", r) }) {
+		words = append(words, splitWords(strings.ReplaceAll(path.Base(part), ".", "_"))...)
+	}
+	for i, word := range words {
+		words[i] = upperFirst(word)
+	}
+	return strings.Join(words, "")
+}
+
+func upperFirst(word string) string {
+	if word == "" {
+		return word
+	}
+	r, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToUpper(r)) + word[size:]
+}
+
+// split a Go identifier into words on case changes, underscores and hyphens. runs of capitals are kept together, e.g. UserID is User and ID
+func splitWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := 0
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '_' || r == '-' {
+			if start < i {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+			continue
+		}
+		if i > start && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) || nextIsLower {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
@@ -0,0 +1,84 @@
+package structgraphql_test
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	structgraphql "github.com/onichandame/struct-graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNaming(t *testing.T) {
+	t.Run("cases", func(t *testing.T) {
+		assert.Equal(t, "fullName", structgraphql.CamelCase("FullName"))
+		assert.Equal(t, "userID", structgraphql.CamelCase("UserID"))
+		assert.Equal(t, "id", structgraphql.CamelCase("ID"))
+		assert.Equal(t, "httpServer", structgraphql.CamelCase("HTTPServer"))
+		assert.Equal(t, "createdAt", structgraphql.CamelCase("created_at"))
+		assert.Equal(t, "STATUS_ACTIVE", structgraphql.ScreamingSnakeCase("StatusActive"))
+		assert.Equal(t, "USER_ID", structgraphql.ScreamingSnakeCase("userID"))
+		assert.Equal(t, "StructGraphqlTestUser", structgraphql.PackageQualified(reflect.TypeOf(User{}), "User"))
+		assert.Equal(t, "StructGraphqlTestPageModelsUser", structgraphql.PackageQualified(reflect.TypeOf(User{}), "Page[github.com/org/models.User]"))
+	})
+	t.Run("applies to outputs, inputs, args and enums", func(t *testing.T) {
+		parser := structgraphql.NewParser(
+			structgraphql.WithTypeName(func(t reflect.Type, name string) string { return "Api" + name }),
+			structgraphql.WithInputSuffix("Input"),
+			structgraphql.WithFieldName(func(name string) string { return strings.ToLower(structgraphql.ScreamingSnakeCase(name)) }),
+			structgraphql.WithEnumValueName(structgraphql.ScreamingSnakeCase),
+		)
+		parser.AddEnumByValues(Status(0), map[string]interface{}{"StatusActive": StatusActive, "StatusInactive": StatusInactive})
+		type Base struct {
+			CreatedBy string
+		}
+		type Profile struct {
+			*Base
+			FullName string
+			Nick     string `graphql:"nickname"`
+			Status   Status
+			Name     Str
+		}
+		output := parser.ParseOutput(new(Profile)).(*graphql.Object)
+		assert.Equal(t, "ApiProfile", output.Name())
+		assert.Equal(t, []string{"created_by", "full_name", "name", "nickname", "status"}, sortedKeys(output.Fields()))
+		assert.Equal(t, "Str", parser.ParseOutput(Str("")).Name())
+		assert.Equal(t, "ApiStatus", parser.ParseOutput(Status(0)).Name())
+		assert.Equal(t, []string{"STATUS_ACTIVE", "STATUS_INACTIVE"}, enumNames(parser.ParseOutput(Status(0)).(*graphql.Enum)))
+		input := parser.ParseInput(new(Profile)).(*graphql.InputObject)
+		assert.Equal(t, "ApiProfileInput", input.Name())
+		assert.Equal(t, []string{"created_by", "full_name", "name", "nickname", "status"}, sortedKeys(input.Fields()))
+		assert.Equal(t, []string{"created_by", "full_name", "name", "nickname", "status"}, sortedKeys(parser.ParseArgs(new(Profile))))
+		schema, err := structgraphql.NewSchemaBuilder(parser).
+			Query("profile", func(args struct {
+				Profile Profile `graphql:"profile"`
+			}) *Profile {
+				return &args.Profile
+			}).
+			Query("empty", func() *Profile { return &Profile{} }).
+			Queries(new(TodoResolver)).
+			Build()
+		assert.Nil(t, err)
+		assert.NotNil(t, schema.QueryType().Fields()["add_todo"])
+		res := graphql.Do(graphql.Params{Schema: schema, RequestString: `{
+			profile(profile:{created_by:"admin",full_name:"John Doe",nickname:"jd",status:STATUS_INACTIVE,name:"john"}){created_by full_name nickname status name}
+			empty{created_by}
+		}`})
+		assert.Nil(t, res.Errors)
+		assert.Equal(t, map[string]interface{}{
+			"profile": map[string]interface{}{"created_by": "admin", "full_name": "John Doe", "nickname": "jd", "status": "STATUS_INACTIVE", "name": "john"},
+			"empty":   map[string]interface{}{"created_by": nil},
+		}, res.Data)
+	})
+}
+
+func enumNames(enum *graphql.Enum) []string {
+	var res []string
+	for _, value := range enum.Values() {
+		res = append(res, value.Name)
+	}
+	sort.Strings(res)
+	return res
+}
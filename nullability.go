@@ -0,0 +1,87 @@
+package structgraphql
+
+import (
+	"reflect"
+
+	"github.com/fatih/structtag"
+	"github.com/graphql-go/graphql"
+)
+
+type Nullability int
+
+const (
+	// fields with a graphql tag are non-null unless they have the nullable option, other fields and list items are nullable
+	NullabilityByTag Nullability = iota
+	// pointers, slices, maps and interfaces are nullable and other values are non-null, for fields and list items alike
+	NullabilityByGoType
+)
+
+// decide which fields and list items are nullable. the nullable, nonnull, nullableitems and nonnullitems tag options take precedence over the policy
+func WithNullability(nullability Nullability) Option {
+	return func(parser *Parser) { parser.nullability = nullability }
+}
+
+// wrap the element type of a field in lists and non-nulls according to the Go type of the field
+func (parser *Parser) decorateFieldType(field *reflect.StructField, t graphql.Type) graphql.Type {
+	var options []string
+	tagged := false
+	if tags, _ := structtag.Parse(string(field.Tag)); tags != nil {
+		if tag, _ := tags.Get(TAG_PREFIX); tag != nil {
+			tagged = true
+			options = tag.Options
+		}
+	}
+	if tag := parser.getJSONTag(field); parser.jsonNullability && tag != nil {
+		tagged = true
+		if tag.HasOption(TAG_OMITEMPTY) {
+			options = append(options, TAG_NULLABLE)
+		}
+	}
+	hasOption := func(name string) bool {
+		for _, option := range options {
+			if option == name {
+				return true
+			}
+		}
+		return false
+	}
+	// the nullability of the field followed by that of the items of each list dimension
+	var levels []bool
+	for goType := field.Type; ; goType = goType.Elem() {
+		nullable := goType.Kind() == reflect.Ptr
+		goType = getType(goType)
+		switch goType.Kind() {
+		case reflect.Slice, reflect.Map, reflect.Interface:
+			nullable = true
+		}
+		if parser.nullability == NullabilityByTag {
+			nullable = len(levels) > 0 || !tagged
+		}
+		if len(levels) == 0 {
+			if hasOption(TAG_NULLABLE) {
+				nullable = true
+			} else if hasOption(TAG_NONNULL) {
+				nullable = false
+			}
+		} else {
+			if hasOption(TAG_NULLABLEITEMS) {
+				nullable = true
+			} else if hasOption(TAG_NONNULLITEMS) {
+				nullable = false
+			}
+		}
+		levels = append(levels, nullable)
+		if goType.Kind() != reflect.Slice || parser.isLeaf(goType) {
+			break
+		}
+	}
+	for i := len(levels) - 1; i >= 0; i-- {
+		if i < len(levels)-1 {
+			t = graphql.NewList(t)
+		}
+		if !levels[i] {
+			t = graphql.NewNonNull(t)
+		}
+	}
+	return t
+}
@@ -1,27 +1,106 @@
 package structgraphql
 
 import (
-	"fmt"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/graphql-go/graphql"
+	goutils "github.com/onichandame/go-utils"
 )
 
+// a Parser is safe for concurrent use. each Go type is parsed once and always maps to the same graphql type
 type Parser struct {
+	// guards the fields below, which entry points lock for a whole parse as parsing a type recursively parses the types it references
+	mu     sync.RWMutex
 	types  map[reflect.Type]graphql.Type
 	inputs map[reflect.Type]graphql.Input
+	names  map[string]namedType
+	// maps parsed as entries are kept apart as the same map may be a JSON scalar elsewhere
+	entries      map[reflect.Type]graphql.Type
+	inputEntries map[reflect.Type]graphql.Input
+	// naming strategies only apply to names derived from Go identifiers
+	typeNamer      func(t reflect.Type, name string) string
+	inputNamer     func(t reflect.Type, name string) string
+	fieldNamer     func(name string) string
+	enumValueNamer func(name string) string
+	// json tags are only consulted for fields without a graphql tag
+	jsonTags        bool
+	jsonNullability bool
+	nullability     Nullability
+	int64Mode       Int64Mode
+	interfaces      []*parsedInterface
+	// the scalars derived scalars take their serialization from, for generating code
+	scalarBases map[*graphql.Scalar]*graphql.Scalar
+	// the types registered by NewParser and its options, which are only part of a schema when referenced
+	builtins map[reflect.Type]interface{}
+	// how to undo the changes of the entry point being parsed, in order
+	undo     []func()
+	tracking bool
 }
 
-func NewParser() *Parser {
+// start recording how to undo the changes to the caches of the parser, for an entry point to roll back a failed parse
+func (parser *Parser) trackChanges() int {
+	parser.tracking = true
+	return len(parser.undo)
+}
+
+// deferred by entry points so that no type parsed by a failed attempt is kept referencing the discarded ones
+func (parser *Parser) rollbackOnPanic(from int) {
+	err := recover()
+	if err != nil {
+		for i := len(parser.undo) - 1; i >= from; i-- {
+			parser.undo[i]()
+		}
+	}
+	// the recorded changes are released as they reference the parsed types
+	for i := from; i < len(parser.undo); i++ {
+		parser.undo[i] = nil
+	}
+	parser.undo = parser.undo[:from]
+	parser.tracking = false
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (parser *Parser) recordChange(undo func()) {
+	if parser.tracking {
+		parser.undo = append(parser.undo, undo)
+	}
+}
+
+func (parser *Parser) setType(t reflect.Type, gqlType graphql.Type) {
+	if prev, ok := parser.types[t]; ok {
+		parser.recordChange(func() { parser.types[t] = prev })
+	} else {
+		parser.recordChange(func() { delete(parser.types, t) })
+	}
+	parser.types[t] = gqlType
+}
+
+func (parser *Parser) setInput(t reflect.Type, input graphql.Input) {
+	if prev, ok := parser.inputs[t]; ok {
+		parser.recordChange(func() { parser.inputs[t] = prev })
+	} else {
+		parser.recordChange(func() { delete(parser.inputs, t) })
+	}
+	parser.inputs[t] = input
+}
+
+func NewParser(opts ...Option) *Parser {
 	var parser Parser
 	parser.inputs = make(map[reflect.Type]graphql.Input)
 	parser.types = make(map[reflect.Type]graphql.Type)
+	parser.names = make(map[string]namedType)
+	parser.entries = make(map[reflect.Type]graphql.Type)
+	parser.inputEntries = make(map[reflect.Type]graphql.Input)
+	parser.scalarBases = make(map[*graphql.Scalar]*graphql.Scalar)
 	parser.types[reflect.TypeOf(time.Time{})] = graphql.DateTime
 	parser.types[reflect.TypeOf(false)] = graphql.Boolean
 	ints := []interface{}{int(0), int8(0), int16(0), int32(0), int64(0), uint(0), uint8(0), uint16(0), uint32(0), uint64(0)}
 	floats := []interface{}{float32(0), float64(0)}
-	strings := []interface{}{string(``), []byte(``)}
+	strings := []interface{}{string(``)}
 	for _, i := range ints {
 		parser.types[reflect.TypeOf(i)] = graphql.Int
 	}
@@ -31,6 +110,28 @@ func NewParser() *Parser {
 	for _, s := range strings {
 		parser.types[reflect.TypeOf(s)] = graphql.String
 	}
+	for t, scalar := range parser.types {
+		parser.inputs[t] = scalar.(*graphql.Scalar)
+	}
+	for _, opt := range opts {
+		opt(&parser)
+	}
+	if int64Scalar := parser.getInt64Scalar(); int64Scalar != graphql.Int {
+		for _, i := range []interface{}{int64(0), uint64(0)} {
+			if parser.types[reflect.TypeOf(i)] == graphql.Int {
+				// like other builtins, Float is not registered by name
+				if int64Scalar == Int64Scalar {
+					parser.registerName(reflect.TypeOf(i), int64Scalar, rootPath(reflect.TypeOf(i)))
+				}
+				parser.types[reflect.TypeOf(i)] = int64Scalar
+				parser.inputs[reflect.TypeOf(i)] = int64Scalar
+			}
+		}
+	}
+	parser.builtins = make(map[reflect.Type]interface{})
+	for t := range parser.types {
+		parser.builtins[t] = nil
+	}
 	return &parser
 }
 
@@ -39,114 +140,142 @@ func (parser *Parser) isTypeLoaded(t reflect.Type) bool {
 	return ok
 }
 
+// byte slices and slices registered as scalars or enums, e.g. json.RawMessage, are leaves rather than lists
+func (parser *Parser) isLeaf(t reflect.Type) bool {
+	if isBytes(t) {
+		return true
+	}
+	for _, gqlType := range []graphql.Type{parser.types[t], parser.inputs[t]} {
+		switch gqlType.(type) {
+		case *graphql.Scalar, *graphql.Enum:
+			return true
+		}
+	}
+	return false
+}
+
+// the element type of possibly nested slices and the number of slice dimensions
+func (parser *Parser) unwrapSlice(t reflect.Type) (reflect.Type, int) {
+	var dims int
+	for t.Kind() == reflect.Slice && !parser.isLeaf(t) {
+		dims++
+		t = getType(t.Elem())
+	}
+	return t, dims
+}
+
 func (parser *Parser) AddEnum(ent interface{}, enum *graphql.Enum) {
+	parser.mu.Lock()
+	defer parser.mu.Unlock()
 	t := getType(ent)
 	if parser.isTypeLoaded(t) {
 		return
 	}
-	parser.types[t] = enum
-	parser.inputs[t] = enum
+	parser.registerName(t, enum, rootPath(t))
+	parser.setType(t, enum)
+	parser.setInput(t, enum)
 }
 
 func (parser *Parser) AddEnumByValues(ent interface{}, values map[string]interface{}) {
+	parser.mu.Lock()
+	defer parser.mu.Unlock()
 	t := getType(ent)
 	if parser.isTypeLoaded(t) {
 		return
 	}
-	name := getName(t)
-	description := getDescription(t)
+	enum := parser.newEnum(t, values)
+	parser.registerName(t, enum, rootPath(t))
+	parser.setType(t, enum)
+	parser.setInput(t, enum)
+}
+
+func (parser *Parser) newEnum(t reflect.Type, values map[string]interface{}) *graphql.Enum {
+	descriptions := getValueDescriptions(t)
+	deprecations := getValueDeprecations(t)
 	valuesMap := make(graphql.EnumValueConfigMap)
 	for name, value := range values {
-		valuesMap[name] = &graphql.EnumValueConfig{Value: value}
+		valuesMap[parser.getEnumValueName(name)] = &graphql.EnumValueConfig{Value: value, Description: descriptions[name], DeprecationReason: deprecations[name]}
 	}
-	enum := graphql.NewEnum(graphql.EnumConfig{
-		Name:        name,
-		Description: description,
+	return graphql.NewEnum(graphql.EnumConfig{
+		Name:        parser.getTypeName(t),
+		Description: getDescription(t),
 		Values:      valuesMap,
 	})
-	parser.types[t] = enum
-	parser.inputs[t] = enum
+}
+
+// enums are shared by outputs and inputs like scalars
+func (parser *Parser) parseEnum(t reflect.Type, path string) {
+	if enum, ok := parser.types[t]; ok {
+		parser.shareInput(t, enum, path)
+		return
+	}
+	if enum, ok := parser.inputs[t]; ok {
+		parser.setType(t, enum)
+		return
+	}
+	values := make(map[string]interface{})
+	for name, value := range getValues(t) {
+		v := reflect.ValueOf(value)
+		if !v.IsValid() || v.Kind() != t.Kind() {
+			panic(newParseError(t, path, "value %v of type %T is not a %v", name, value, t))
+		}
+		values[name] = v.Convert(t).Interface()
+	}
+	enum := parser.newEnum(t, values)
+	parser.registerName(t, enum, path)
+	parser.setType(t, enum)
+	parser.setInput(t, enum)
 }
 
 func (parser *Parser) AddScalar(ent interface{}, value *graphql.Scalar) {
+	parser.mu.Lock()
+	defer parser.mu.Unlock()
 	t := getType(ent)
 	if parser.isTypeLoaded(t) {
 		return
 	}
-	parser.types[t] = value
-	parser.inputs[t] = value
+	parser.registerName(t, value, rootPath(t))
+	parser.setType(t, value)
+	parser.setInput(t, value)
 }
 
-func (parser *Parser) ParseOutput(ent interface{}, opts ...interface{}) graphql.Type {
+func (parser *Parser) ParseOutput(ent interface{}) graphql.Type {
+	res, err := parser.TryParseOutput(ent)
+	goutils.Assert(err)
+	return res
+}
+
+// same as ParseOutput but returns a *ParseError instead of panicking
+func (parser *Parser) TryParseOutput(ent interface{}) (res graphql.Type, err error) {
+	defer goutils.RecoverToErr(&err)
+	parser.mu.Lock()
+	defer parser.mu.Unlock()
+	defer parser.rollbackOnPanic(parser.trackChanges())
 	t := getType(ent)
-	t, sliceDims := unwrapSlice(t)
+	return parser.parseOutput(t, rootPath(t)), nil
+}
+
+func (parser *Parser) parseOutput(t reflect.Type, path string) graphql.Type {
+	t, sliceDims := parser.unwrapSlice(t)
 	t = getType(t)
 	if !parser.isTypeLoaded(t) {
-		visited := make(map[reflect.Type]interface{})
-		if len(opts) > 0 {
-			if v, ok := opts[0].(map[reflect.Type]interface{}); ok {
-				visited = v
-			}
-		}
-		if _, ok := visited[t]; ok {
-			panic(fmt.Errorf("when loading output type there must not be a cyclic reference at %v", t.Name()))
-		}
-		visited[t] = nil
 		if t != reflect.TypeOf(time.Time{}) && t.Kind() == reflect.Struct {
 			fields := make(graphql.Fields)
-			var loadStruct func(t reflect.Type)
-			loadStruct = func(t reflect.Type) {
-				for i := 0; i < t.NumField(); i++ {
-					field := t.Field(i)
-					if field.Anonymous {
-						loadStruct(field.Type)
-					} else {
-						fieldType := getType(field.Type)
-						name := getFieldName(&field)
-						var sliceDims int
-						elemType, sliceDims := unwrapSlice(fieldType)
-						fieldType = getType(elemType)
-						var fieldtype graphql.Type
-						if ft, ok := parser.types[fieldType]; !ok {
-							fieldtype = parser.ParseOutput(fieldType, visited)
-						} else {
-							fieldtype = ft
-						}
-						for dim := 0; dim < sliceDims; dim++ {
-							fieldtype = graphql.NewList(fieldtype)
-						}
-						fieldtype = decorateFieldType(&field, fieldtype)
-						fields[name] = &graphql.Field{Type: fieldtype, Description: getDescription(fieldType), Name: getName(fieldType)}
-					}
-				}
-			}
-			loadStruct(t)
-			parser.types[t] = graphql.NewObject(graphql.ObjectConfig{
-				Fields:      fields,
-				Name:        getName(t),
+			interfaces := parser.getInterfaces(t)
+			// the object is registered before its fields are loaded so that cyclic references resolve to it
+			object := graphql.NewObject(graphql.ObjectConfig{
+				Fields:      graphql.FieldsThunk(func() graphql.Fields { return withInterfaceFields(fields, interfaces) }),
+				Interfaces:  parser.getInterfaceTypes(interfaces),
+				Name:        parser.getTypeName(t),
 				Description: getDescription(t),
 			})
+			parser.registerName(t, object, path)
+			parser.setType(t, object)
+			parser.loadOutputFields(t, path, fields)
+		} else if isEnumerated(t) {
+			parser.parseEnum(t, path)
 		} else {
-			var baseType *graphql.Scalar
-			if isID(t) {
-				baseType = graphql.ID
-			} else if t == reflect.TypeOf(time.Time{}) {
-				baseType = graphql.DateTime
-			} else {
-				switch t.Kind() {
-				case reflect.Float32, reflect.Float64:
-					baseType = graphql.Float
-				case reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int8, reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint8:
-					baseType = graphql.Int
-				case reflect.String:
-					baseType = graphql.String
-				case reflect.Bool:
-					baseType = graphql.Boolean
-				default:
-					panic(fmt.Errorf("type %v not supported", t.Kind()))
-				}
-			}
-			parser.types[t] = graphql.NewScalar(graphql.ScalarConfig{Serialize: baseType.Serialize, ParseValue: baseType.ParseValue, ParseLiteral: baseType.ParseLiteral, Name: getName(t), Description: getDescription(t)})
+			parser.parseScalar(t, path)
 		}
 	}
 	res := parser.types[t]
@@ -156,74 +285,105 @@ func (parser *Parser) ParseOutput(ent interface{}, opts ...interface{}) graphql.
 	return res
 }
 
-func (parser *Parser) ParseInput(ent interface{}, opts ...interface{}) graphql.Input {
-	t := getType(ent)
-	t, sliceDims := unwrapSlice(t)
-	t = getType(t)
-	if _, ok := parser.inputs[t]; !ok {
-		visited := make(map[reflect.Type]interface{})
-		if len(opts) > 0 {
-			if v, ok := opts[0].(map[reflect.Type]interface{}); ok {
-				visited = v
+// load the fields and methods of struct t into fields
+func (parser *Parser) loadOutputFields(t reflect.Type, path string, fields graphql.Fields) {
+	var loadStruct func(st reflect.Type, index []int)
+	loadStruct = func(st reflect.Type, index []int) {
+		for i := 0; i < st.NumField(); i++ {
+			field := st.Field(i)
+			if parser.isOmitted(&field, TAG_OUTPUT) {
+				continue
+			}
+			fieldIndex := append(append([]int{}, index...), i)
+			if field.Anonymous {
+				loadStruct(getType(field.Type), fieldIndex)
+			} else {
+				fieldType := getType(field.Type)
+				name := parser.getFieldName(&field)
+				var sliceDims int
+				elemType, sliceDims := parser.unwrapSlice(fieldType)
+				fieldType = getType(elemType)
+				resolve := resolveStructField(t, fieldIndex)
+				var elem graphql.Type
+				if hasEntries(&field) {
+					elem = parser.parseOutputEntries(fieldType, sliceDims, fieldPath(path, &field, sliceDims))
+					resolve = resolveEntries(resolve)
+				} else {
+					elem = parser.parseOutput(fieldType, fieldPath(path, &field, sliceDims))
+				}
+				fieldtype := parser.decorateFieldType(&field, elem)
+				fields[name] = &graphql.Field{Type: fieldtype, Description: getFieldDescription(&field, fieldType), DeprecationReason: getDeprecationReason(&field), Name: getName(fieldType), Resolve: resolve}
 			}
 		}
-		if _, ok := visited[t]; ok {
-			panic(fmt.Errorf("when loading input type there must not be a cyclic reference at %v", t.Name()))
+	}
+	loadStruct(t, nil)
+	for name, method := range getMethods(t) {
+		m, ok := reflect.PtrTo(t).MethodByName(method)
+		if !ok {
+			panic(newParseError(t, path, "method %v not found", method))
 		}
-		visited[t] = nil
+		fields[name] = parser.parseField(m.Func, path+"."+method+"()", true)
+	}
+}
+
+func (parser *Parser) ParseInput(ent interface{}) graphql.Input {
+	res, err := parser.TryParseInput(ent)
+	goutils.Assert(err)
+	return res
+}
+
+// same as ParseInput but returns a *ParseError instead of panicking
+func (parser *Parser) TryParseInput(ent interface{}) (res graphql.Input, err error) {
+	defer goutils.RecoverToErr(&err)
+	parser.mu.Lock()
+	defer parser.mu.Unlock()
+	defer parser.rollbackOnPanic(parser.trackChanges())
+	t := getType(ent)
+	return parser.parseInput(t, rootPath(t)), nil
+}
+
+func (parser *Parser) parseInput(t reflect.Type, path string) graphql.Input {
+	t, sliceDims := parser.unwrapSlice(t)
+	t = getType(t)
+	if _, ok := parser.inputs[t]; !ok {
 		if t.Kind() == reflect.Struct && t != reflect.TypeOf(time.Time{}) {
 			fields := make(graphql.InputObjectConfigFieldMap)
+			// the input object is registered before its fields are loaded so that recursive inputs resolve to it
+			object := graphql.NewInputObject(graphql.InputObjectConfig{
+				Name:        parser.getInputName(t),
+				Description: getDescription(t),
+				Fields:      graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap { return fields }),
+			})
+			parser.registerName(t, object, path)
+			parser.setInput(t, object)
 			var loadStruct func(t reflect.Type)
 			loadStruct = func(t reflect.Type) {
 				for i := 0; i < t.NumField(); i++ {
 					field := t.Field(i)
+					if parser.isOmitted(&field, TAG_INPUT) {
+						continue
+					}
 					if field.Anonymous {
-						loadStruct(field.Type)
+						loadStruct(getType(field.Type))
 					} else {
 						fieldType := getType(field.Type)
-						name := getFieldName(&field)
+						name := parser.getFieldName(&field)
 						var sliceDims int
-						elemType, sliceDims := unwrapSlice(fieldType)
+						elemType, sliceDims := parser.unwrapSlice(fieldType)
 						fieldType = getType(elemType)
-						var fieldtype graphql.Type
-						if ft, ok := parser.inputs[fieldType]; !ok {
-							fieldtype = parser.ParseInput(fieldType, visited)
-						} else {
-							fieldtype = ft
-						}
-						for dim := 0; dim < sliceDims; dim++ {
-							fieldtype = graphql.NewList(fieldtype)
-						}
-						fieldtype = decorateFieldType(&field, fieldtype)
-						fields[name] = &graphql.InputObjectFieldConfig{Type: fieldtype, Description: getDescription(fieldType), DefaultValue: getDefault(fieldType)}
+						fieldpath := fieldPath(path, &field, sliceDims)
+						fieldtype := parser.decorateFieldType(&field, parser.parseInputElem(&field, fieldType, sliceDims, fieldpath))
+						defaultValue := getFieldDefault(&field, fieldType, fieldtype, fieldpath)
+						fieldtype = nullableWithDefault(fieldtype, defaultValue)
+						fields[name] = &graphql.InputObjectFieldConfig{Type: fieldtype, Description: getFieldDescription(&field, fieldType), DefaultValue: defaultValue}
 					}
 				}
 			}
 			loadStruct(t)
-			parser.inputs[t] = graphql.NewInputObject(graphql.InputObjectConfig{
-				Name:        getName(t),
-				Description: getDescription(t),
-				Fields:      fields,
-			})
+		} else if isEnumerated(t) {
+			parser.parseEnum(t, path)
 		} else {
-			var basetype *graphql.Scalar
-			if t == reflect.TypeOf(time.Time{}) {
-				basetype = graphql.DateTime
-			} else {
-				switch t.Kind() {
-				case reflect.Float32, reflect.Float64:
-					basetype = graphql.Float
-				case reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int8, reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint8:
-					basetype = graphql.Int
-				case reflect.String:
-					basetype = graphql.String
-				case reflect.Bool:
-					basetype = graphql.Boolean
-				default:
-					panic(fmt.Errorf("type %v not supported", t.Kind()))
-				}
-			}
-			parser.inputs[t] = graphql.NewScalar(graphql.ScalarConfig{Name: getName(t), Description: getDescription(t), Serialize: basetype.Serialize, ParseValue: basetype.ParseValue, ParseLiteral: basetype.ParseLiteral})
+			parser.parseScalar(t, path)
 		}
 	}
 	res := parser.inputs[t]
@@ -233,31 +393,122 @@ func (parser *Parser) ParseInput(ent interface{}, opts ...interface{}) graphql.I
 	return res
 }
 
+// the element type of an input field or argument, without its lists and non-nulls
+func (parser *Parser) parseInputElem(field *reflect.StructField, t reflect.Type, sliceDims int, path string) graphql.Input {
+	if hasEntries(field) {
+		return parser.parseInputEntries(t, sliceDims, path)
+	}
+	return parser.parseInput(t, path)
+}
+
+// use the scalar or enum already generated for t as an input. other outputs, e.g. unions and interfaces, cannot be inputs
+func (parser *Parser) shareInput(t reflect.Type, output graphql.Type, path string) {
+	switch output := output.(type) {
+	case *graphql.Scalar:
+		parser.setInput(t, output)
+	case *graphql.Enum:
+		parser.setInput(t, output)
+	default:
+		panic(newParseError(t, path, "%v %v cannot be an input", describeType(output), output.Name()))
+	}
+}
+
+// scalars are shared by outputs and inputs so that a type never gets two scalars of the same name
+func (parser *Parser) parseScalar(t reflect.Type, path string) {
+	if scalar, ok := parser.types[t]; ok {
+		parser.shareInput(t, scalar, path)
+		return
+	}
+	if scalar, ok := parser.inputs[t]; ok {
+		parser.setType(t, scalar)
+		return
+	}
+	var baseType *graphql.Scalar
+	if isID(t) {
+		baseType = graphql.ID
+	} else if isBytes(t) {
+		if t.Name() == "" {
+			parser.registerName(t, Base64Scalar, path)
+			parser.setType(t, Base64Scalar)
+			parser.setInput(t, Base64Scalar)
+			return
+		}
+		baseType = Base64Scalar
+	} else if t == reflect.TypeOf(time.Time{}) {
+		baseType = graphql.DateTime
+	} else {
+		switch t.Kind() {
+		case reflect.Float32, reflect.Float64:
+			baseType = graphql.Float
+		case reflect.Int64, reflect.Uint64:
+			baseType = parser.getInt64Scalar()
+		case reflect.Int, reflect.Int16, reflect.Int32, reflect.Int8, reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint8:
+			baseType = graphql.Int
+		case reflect.String:
+			baseType = graphql.String
+		case reflect.Bool:
+			baseType = graphql.Boolean
+		case reflect.Map:
+			// maps of every type share the JSON scalar
+			parser.registerName(t, JSONScalar, path)
+			parser.setType(t, JSONScalar)
+			parser.setInput(t, JSONScalar)
+			return
+		default:
+			panic(newParseError(t, path, "type %v not supported", t.Kind()))
+		}
+	}
+	scalar := graphql.NewScalar(graphql.ScalarConfig{Serialize: baseType.Serialize, ParseValue: baseType.ParseValue, ParseLiteral: baseType.ParseLiteral, Name: parser.getTypeName(t), Description: getDescription(t)})
+	parser.registerName(t, scalar, path)
+	parser.scalarBases[scalar] = baseType
+	parser.recordChange(func() { delete(parser.scalarBases, scalar) })
+	parser.setType(t, scalar)
+	parser.setInput(t, scalar)
+}
+
 // parse all args as a struct
 func (parser *Parser) ParseArgs(ent interface{}) graphql.FieldConfigArgument {
+	res, err := parser.TryParseArgs(ent)
+	goutils.Assert(err)
+	return res
+}
+
+// same as ParseArgs but returns a *ParseError instead of panicking
+func (parser *Parser) TryParseArgs(ent interface{}) (res graphql.FieldConfigArgument, err error) {
+	defer goutils.RecoverToErr(&err)
+	parser.mu.Lock()
+	defer parser.mu.Unlock()
+	defer parser.rollbackOnPanic(parser.trackChanges())
 	t := getType(ent)
+	return parser.parseArgs(t, rootPath(t)), nil
+}
+
+func (parser *Parser) parseArgs(t reflect.Type, path string) graphql.FieldConfigArgument {
 	if t.Kind() != reflect.Struct {
-		panic(fmt.Errorf("args must be passed as a struct"))
+		panic(newParseError(t, path, "args must be passed as a struct"))
 	}
 	args := make(graphql.FieldConfigArgument)
 	var loadStruct func(t reflect.Type)
 	loadStruct = func(t reflect.Type) {
 		for i := 0; i < t.NumField(); i++ {
 			field := t.Field(i)
+			if parser.isOmitted(&field, TAG_INPUT) {
+				continue
+			}
 			if field.Anonymous {
-				loadStruct(field.Type)
+				loadStruct(getType(field.Type))
 			} else {
 				fieldType := getType(field.Type)
-				fieldType, sliceDims := unwrapSlice(fieldType)
-				argType := parser.ParseInput(fieldType)
-				for i := 0; i < sliceDims; i++ {
-					argType = graphql.NewList(argType)
-				}
-				argType = decorateFieldType(&field, argType)
-				args[getFieldName(&field)] = &graphql.ArgumentConfig{
+				fieldType, sliceDims := parser.unwrapSlice(fieldType)
+				fieldType = getType(fieldType)
+				fieldpath := fieldPath(path, &field, sliceDims)
+				argType := parser.decorateFieldType(&field, parser.parseInputElem(&field, fieldType, sliceDims, fieldpath))
+				defaultValue := getFieldDefault(&field, fieldType, argType, fieldpath)
+				argType = nullableWithDefault(argType, defaultValue)
+				args[parser.getFieldName(&field)] = &graphql.ArgumentConfig{
 					Type:         argType,
-					Description:  getDescription(fieldType),
-					DefaultValue: getDefault(fieldType),
+					Description:  getFieldDescription(&field, fieldType),
+					DefaultValue: defaultValue,
 				}
 			}
 		}
@@ -1,8 +1,14 @@
 package structgraphql_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -24,6 +30,71 @@ func (Int) GetDescription() string { return `Int8bit` }
 type ID uint
 
 func (ID) IsID() bool { return true }
+
+type User struct {
+	Name  string  `graphql:"name"`
+	Posts []*Post `graphql:"posts"`
+}
+
+type Post struct {
+	Title  string `graphql:"title"`
+	Author *User  `graphql:"author"`
+}
+
+func sortedKeys(m interface{}) []string {
+	var res []string
+	for _, key := range reflect.ValueOf(m).MapKeys() {
+		res = append(res, key.String())
+	}
+	sort.Strings(res)
+	return res
+}
+
+type Customer struct {
+	First   string `graphql:"first"`
+	Last    string `graphql:"last"`
+	history []string
+}
+
+func (c Customer) FullName() string { return c.First + " " + c.Last }
+func (c *Customer) Orders(ctx context.Context, args struct {
+	Limit int `graphql:"limit"`
+}) ([]string, error) {
+	if args.Limit < 0 {
+		return nil, errors.New("negative limit")
+	}
+	if args.Limit > len(c.history) {
+		args.Limit = len(c.history)
+	}
+	return c.history[:args.Limit], nil
+}
+func (Customer) GetMethods() map[string]string {
+	return map[string]string{"fullName": "FullName", "orders": "Orders"}
+}
+
+type Account struct {
+	Name string `graphql:"name"`
+}
+
+func (Account) GetInputName() string { return "CreateAccount" }
+
+type Broken struct{}
+
+func (Broken) GetMethods() map[string]string { return map[string]string{"missing": "Missing"} }
+
+type Retried struct {
+	Next   *RetriedNext `graphql:"next,nullable"`
+	Result Outcome      `graphql:"result"`
+}
+
+type RetriedNext struct {
+	Prev *Retried `graphql:"prev,nullable"`
+}
+
+type Outcome interface{ isOutcome() }
+
+func (*RetriedNext) isOutcome() {}
+
 func TestParser(t *testing.T) {
 	t.Run("output", func(t *testing.T) {
 		t.Run("can parse primitives", func(t *testing.T) {
@@ -65,12 +136,50 @@ func TestParser(t *testing.T) {
 			})
 		})
 		t.Run("can parse objects", func(t *testing.T) {
-			t.Run("throws at cyclic reference", func(t *testing.T) {
+			t.Run("self reference", func(t *testing.T) {
 				parser := structgraphql.NewParser()
 				type Obj struct {
-					Obj *Obj
+					Parent   *Obj   `graphql:"parent,nullable"`
+					Children []*Obj `graphql:"children"`
 				}
-				assert.Panics(t, func() { parser.ParseOutput(new(Obj)) })
+				objType := parser.ParseOutput(new(Obj))
+				assert.IsType(t, new(graphql.Object), objType)
+				obj := objType.(*graphql.Object)
+				assert.Equal(t, objType, obj.Fields()["parent"].Type)
+				assert.Equal(t, objType, obj.Fields()["children"].Type.(*graphql.NonNull).OfType.(*graphql.List).OfType)
+				_, err := graphql.NewSchema(graphql.SchemaConfig{Query: obj})
+				assert.Nil(t, err)
+			})
+			t.Run("mutual reference", func(t *testing.T) {
+				parser := structgraphql.NewParser()
+				userType := parser.ParseOutput(new(User)).(*graphql.Object)
+				postType := userType.Fields()["posts"].Type.(*graphql.NonNull).OfType.(*graphql.List).OfType.(*graphql.Object)
+				assert.Equal(t, userType, postType.Fields()["author"].Type.(*graphql.NonNull).OfType)
+				assert.Equal(t, postType, parser.ParseOutput(new(Post)))
+				_, err := graphql.NewSchema(graphql.SchemaConfig{Query: userType})
+				assert.Nil(t, err)
+			})
+			t.Run("methods", func(t *testing.T) {
+				parser := structgraphql.NewParser()
+				customerType := parser.ParseOutput(new(Customer)).(*graphql.Object)
+				assert.Equal(t, graphql.String, customerType.Fields()["fullName"].Type)
+				assert.NotNil(t, customerType.Fields()["orders"].Args)
+				schema, err := structgraphql.NewSchemaBuilder(parser).
+					Query("customer", func() Customer { return Customer{First: "John", Last: "Doe", history: []string{"a", "b"}} }).
+					Query("customers", func() []*Customer { return []*Customer{{First: "Jane", Last: "Doe"}} }).
+					Build()
+				assert.Nil(t, err)
+				res := graphql.Do(graphql.Params{Schema: schema, RequestString: `{customer{fullName orders(limit:1)} customers{fullName}}`})
+				assert.Nil(t, res.Errors)
+				assert.Equal(t, map[string]interface{}{
+					"customer":  map[string]interface{}{"fullName": "John Doe", "orders": []interface{}{"a"}},
+					"customers": []interface{}{map[string]interface{}{"fullName": "Jane Doe"}},
+				}, res.Data)
+				res = graphql.Do(graphql.Params{Schema: schema, RequestString: `{customer{orders(limit:-1)}}`})
+				assert.Len(t, res.Errors, 1)
+				_, err = parser.TryParseOutput(new(Broken))
+				var parseErr *structgraphql.ParseError
+				assert.ErrorAs(t, err, &parseErr)
 			})
 			t.Run("plain object", func(t *testing.T) {
 				parser := structgraphql.NewParser()
@@ -162,12 +271,24 @@ func TestParser(t *testing.T) {
 			})
 		})
 		t.Run("can parse objects", func(t *testing.T) {
-			t.Run("throws when circular dependency", func(t *testing.T) {
+			t.Run("recursive object", func(t *testing.T) {
 				parser := structgraphql.NewParser()
-				type Input struct {
-					Input *Input
+				type Filter struct {
+					Name *string   `graphql:"name,nullable"`
+					And  []*Filter `graphql:"and,nullable"`
+					Or   []*Filter `graphql:"or,nullable"`
 				}
-				assert.Panics(t, func() { parser.ParseInput(new(Input)) })
+				inputType := parser.ParseInput(new(Filter))
+				assert.IsType(t, new(graphql.InputObject), inputType)
+				input := inputType.(*graphql.InputObject)
+				assert.Equal(t, inputType, input.Fields()["and"].Type.(*graphql.List).OfType)
+				assert.Equal(t, inputType, input.Fields()["or"].Type.(*graphql.List).OfType)
+			})
+			t.Run("mutually recursive objects", func(t *testing.T) {
+				parser := structgraphql.NewParser()
+				userType := parser.ParseInput(new(User)).(*graphql.InputObject)
+				postType := userType.Fields()["posts"].Type.(*graphql.NonNull).OfType.(*graphql.List).OfType.(*graphql.InputObject)
+				assert.Equal(t, userType, postType.Fields()["author"].Type.(*graphql.NonNull).OfType)
 			})
 			t.Run("plain object", func(t *testing.T) {
 				parser := structgraphql.NewParser()
@@ -258,6 +379,28 @@ func TestParser(t *testing.T) {
 			assert.NotNil(t, input.Fields()["id"])
 			assert.IsType(t, new(graphql.NonNull), input.Fields()["id"].Type)
 		})
+		t.Run("recursive objects", func(t *testing.T) {
+			parser := structgraphql.NewParser()
+			type Filter struct {
+				And []*Filter `graphql:"and,nullable"`
+				Or  []*Filter `graphql:"or,nullable"`
+			}
+			type Args struct {
+				Where *Filter `graphql:"where,nullable"`
+			}
+			argsType := parser.ParseArgs(new(Args))
+			assert.IsType(t, new(graphql.InputObject), argsType["where"].Type)
+			where := argsType["where"].Type.(*graphql.InputObject)
+			assert.Equal(t, where, where.Fields()["and"].Type.(*graphql.List).OfType)
+			_, err := graphql.NewSchema(graphql.SchemaConfig{Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "query",
+				Fields: graphql.Fields{"find": &graphql.Field{
+					Type: graphql.Boolean,
+					Args: argsType,
+				}},
+			})})
+			assert.Nil(t, err)
+		})
 		t.Run("anonymous fields", func(t *testing.T) {
 			parser := structgraphql.NewParser()
 			type Embedded struct {
@@ -269,6 +412,333 @@ func TestParser(t *testing.T) {
 			assert.NotNil(t, argsType[`str`])
 		})
 	})
+	t.Run("field descriptions and deprecation", func(t *testing.T) {
+		parser := structgraphql.NewParser(structgraphql.WithInputSuffix("Input"))
+		type Contact struct {
+			Email string `graphql:"email" gqldesc:"Primary contact email, if any"`
+			Phone string `graphql:"phone" gqldeprecated:"use contacts"`
+			Count Int    `graphql:"count"`
+		}
+		type Args struct {
+			Contact Contact `graphql:"contact" gqldesc:"The contact"`
+			Count   Int     `graphql:"count"`
+		}
+		output := parser.ParseOutput(new(Contact)).(*graphql.Object)
+		assert.Equal(t, "Primary contact email, if any", output.Fields()["email"].Description)
+		assert.Equal(t, "", output.Fields()["email"].DeprecationReason)
+		assert.Equal(t, "use contacts", output.Fields()["phone"].DeprecationReason)
+		assert.Equal(t, Int(0).GetDescription(), output.Fields()["count"].Description)
+		input := parser.ParseInput(new(Contact)).(*graphql.InputObject)
+		assert.Equal(t, "Primary contact email, if any", input.Fields()["email"].Description())
+		assert.Equal(t, Int(0).GetDescription(), input.Fields()["count"].Description())
+		args := parser.ParseArgs(new(Args))
+		assert.Equal(t, "The contact", args["contact"].Description)
+		assert.Equal(t, Int(0).GetDescription(), args["count"].Description)
+		assert.NotNil(t, input.Fields()["phone"])
+		deprecatedArgs, err := parser.TryParseArgs(struct {
+			Phone string `graphql:"phone" gqldeprecated:"use contacts"`
+		}{})
+		assert.Nil(t, err)
+		assert.NotNil(t, deprecatedArgs["phone"])
+	})
+	t.Run("field defaults", func(t *testing.T) {
+		parser := structgraphql.NewParser(structgraphql.WithInputSuffix("Input"))
+		parser.AddEnumByValues(Status(0), map[string]interface{}{"ACTIVE": StatusActive, "INACTIVE": StatusInactive})
+		type Args struct {
+			Limit  int        `graphql:"limit,default=20"`
+			Small  int8       `graphql:"small,nullable,default=-3"`
+			Ratio  float32    `graphql:"ratio,default=0.5"`
+			Active bool       `graphql:"active,default=true"`
+			Name   Str        `graphql:"name,default=jimmy"`
+			Plain  Str        `graphql:"plain"`
+			Status Status     `graphql:"status,default=INACTIVE"`
+			Since  time.Time  `graphql:"since,default=2021-01-02T15:04:05Z"`
+			IDs    []int      `graphql:"ids,default=[1, 2,3]"`
+			Matrix [][]string `graphql:"matrix,default=[[a,b],[]]"`
+			Single []Status   `graphql:"single,default=ACTIVE"`
+		}
+		args := parser.ParseArgs(new(Args))
+		assert.Equal(t, 20, args["limit"].DefaultValue)
+		assert.Equal(t, -3, args["small"].DefaultValue)
+		assert.Equal(t, 0.5, args["ratio"].DefaultValue)
+		assert.Equal(t, true, args["active"].DefaultValue)
+		assert.Equal(t, "jimmy", args["name"].DefaultValue)
+		assert.Equal(t, Str("").GetDefault(), args["plain"].DefaultValue)
+		assert.Equal(t, StatusInactive, args["status"].DefaultValue)
+		assert.Equal(t, time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC), args["since"].DefaultValue)
+		assert.Equal(t, []interface{}{1, 2, 3}, args["ids"].DefaultValue)
+		assert.Equal(t, []interface{}{[]interface{}{"a", "b"}, []interface{}{}}, args["matrix"].DefaultValue)
+		assert.Equal(t, []interface{}{StatusActive}, args["single"].DefaultValue)
+		input := parser.ParseInput(new(Args)).(*graphql.InputObject)
+		assert.Equal(t, 20, input.Fields()["limit"].DefaultValue)
+		var decoded Args
+		assert.Nil(t, parser.DecodeArgs(map[string]interface{}{"matrix": args["matrix"].DefaultValue, "status": args["status"].DefaultValue}, &decoded))
+		assert.Equal(t, [][]string{{"a", "b"}, {}}, decoded.Matrix)
+		t.Run("applies to omitted arguments and input fields", func(t *testing.T) {
+			assert.Equal(t, "Int", args["limit"].Type.String())
+			assert.Equal(t, "Int", input.Fields()["limit"].Type.String())
+			var fromArgs, fromInput Args
+			schema, err := structgraphql.NewSchemaBuilder(parser).
+				Query("args", func(args Args) bool {
+					fromArgs = args
+					return true
+				}).
+				Query("input", func(args struct {
+					Input Args `graphql:"input"`
+				}) bool {
+					fromInput = args.Input
+					return true
+				}).
+				Build()
+			assert.Nil(t, err)
+			res := graphql.Do(graphql.Params{Schema: schema, RequestString: `{args input(input:{})}`})
+			assert.Nil(t, res.Errors)
+			for _, received := range []Args{fromArgs, fromInput} {
+				assert.Equal(t, 20, received.Limit)
+				assert.Equal(t, int8(-3), received.Small)
+				assert.Equal(t, true, received.Active)
+				assert.Equal(t, Str("jimmy"), received.Name)
+				assert.Equal(t, StatusInactive, received.Status)
+				assert.Equal(t, []int{1, 2, 3}, received.IDs)
+				assert.Equal(t, []Status{StatusActive}, received.Single)
+			}
+		})
+		t.Run("reports invalid defaults", func(t *testing.T) {
+			type Int struct {
+				Limit int8 `graphql:"limit,default=300"`
+			}
+			type Enum struct {
+				Status Status `graphql:"status,default=UNKNOWN"`
+			}
+			type Bool struct {
+				Active bool `graphql:"active,default=yes"`
+			}
+			type Object struct {
+				Int Int `graphql:"int,default=1"`
+			}
+			var parseErr *structgraphql.ParseError
+			for _, ent := range []interface{}{new(Int), new(Enum), new(Bool), new(Object)} {
+				_, err := parser.TryParseArgs(ent)
+				assert.ErrorAs(t, err, &parseErr)
+				_, err = parser.TryParseInput(ent)
+				assert.ErrorAs(t, err, &parseErr)
+			}
+			_, err := parser.TryParseArgs(new(Int))
+			assert.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, "Int.Limit", parseErr.Path)
+		})
+		t.Run("keeps 64-bit defaults", func(t *testing.T) {
+			type Args struct {
+				Huge uint64 `graphql:"huge,default=18446744073709551615"`
+				Low  int64  `graphql:"low,default=-9223372036854775808"`
+			}
+			args := structgraphql.NewParser(structgraphql.WithInt64(structgraphql.Int64AsString)).ParseArgs(new(Args))
+			assert.Equal(t, uint64(math.MaxUint64), args["huge"].DefaultValue)
+			assert.Equal(t, int64(math.MinInt64), args["low"].DefaultValue)
+			_, err := structgraphql.NewParser().TryParseArgs(new(Args))
+			assert.Contains(t, err.Error(), "default 18446744073709551615 is not a valid Int")
+		})
+	})
+	t.Run("omitted fields", func(t *testing.T) {
+		parser := structgraphql.NewParser(structgraphql.WithInputSuffix("Input"))
+		type embedded struct {
+			Promoted string `graphql:"promoted"`
+		}
+		type Account struct {
+			embedded
+			Name         string     `graphql:"name"`
+			PasswordHash string     `graphql:"-"`
+			CreatedAt    time.Time  `graphql:"createdAt" gqlinput:"-"`
+			Password     string     `graphql:"password" gqloutput:"-"`
+			mutex        sync.Mutex `graphql:"mutex"`
+		}
+		output := parser.ParseOutput(new(Account)).(*graphql.Object)
+		assert.Equal(t, []string{"createdAt", "name", "promoted"}, sortedKeys(output.Fields()))
+		input := parser.ParseInput(new(Account)).(*graphql.InputObject)
+		assert.Equal(t, []string{"name", "password", "promoted"}, sortedKeys(input.Fields()))
+		args := parser.ParseArgs(new(Account))
+		assert.Equal(t, []string{"name", "password", "promoted"}, sortedKeys(args))
+		var account Account
+		assert.Nil(t, parser.DecodeArgs(map[string]interface{}{"promoted": "p", "password": "secret", "createdAt": "now", "mutex": 1}, &account))
+		assert.Equal(t, "p", account.Promoted)
+		assert.Equal(t, "secret", account.Password)
+		assert.True(t, account.CreatedAt.IsZero())
+	})
+	t.Run("nullability", func(t *testing.T) {
+		type Obj struct {
+			Value       string  `graphql:"value"`
+			Pointer     *string `graphql:"pointer"`
+			Untagged    string
+			List        []string   `graphql:"list"`
+			Pointers    []*string  `graphql:"pointers"`
+			Matrix      [][]int    `graphql:"matrix"`
+			Nullable    string     `graphql:"nullable,nullable"`
+			NonNull     *string    `graphql:"nonnull,nonnull"`
+			Items       []*string  `graphql:"items,nonnullitems"`
+			ItemsOfPtrs *[]*string `graphql:"itemsOfPtrs,nullable,nullableitems"`
+		}
+		typeString := func(field graphql.Type) string { return field.String() }
+		t.Run("by tag", func(t *testing.T) {
+			parser := structgraphql.NewParser()
+			output := parser.ParseOutput(new(Obj)).(*graphql.Object).Fields()
+			assert.Equal(t, "String!", typeString(output["value"].Type))
+			assert.Equal(t, "String!", typeString(output["pointer"].Type))
+			assert.Equal(t, "String", typeString(output["Untagged"].Type))
+			assert.Equal(t, "[String]!", typeString(output["list"].Type))
+			assert.Equal(t, "[[Int]]!", typeString(output["matrix"].Type))
+			assert.Equal(t, "String", typeString(output["nullable"].Type))
+			assert.Equal(t, "String!", typeString(output["nonnull"].Type))
+			assert.Equal(t, "[String!]!", typeString(output["items"].Type))
+			assert.Equal(t, "[String]", typeString(output["itemsOfPtrs"].Type))
+			methods := parser.ParseOutput(Customer{}).(*graphql.Object).Fields()
+			assert.Equal(t, "String", typeString(methods["fullName"].Type))
+			assert.Equal(t, "[String]", typeString(methods["orders"].Type))
+			assert.Equal(t, "[Customer]", typeString(parser.Field(func() []*Customer { return nil }).Type))
+		})
+		t.Run("by go type", func(t *testing.T) {
+			parser := structgraphql.NewParser(structgraphql.WithNullability(structgraphql.NullabilityByGoType), structgraphql.WithInputSuffix("Input"))
+			output := parser.ParseOutput(new(Obj)).(*graphql.Object).Fields()
+			assert.Equal(t, "String!", typeString(output["value"].Type))
+			assert.Equal(t, "String", typeString(output["pointer"].Type))
+			assert.Equal(t, "String!", typeString(output["Untagged"].Type))
+			assert.Equal(t, "[String!]", typeString(output["list"].Type))
+			assert.Equal(t, "[String]", typeString(output["pointers"].Type))
+			assert.Equal(t, "[[Int!]]", typeString(output["matrix"].Type))
+			assert.Equal(t, "String", typeString(output["nullable"].Type))
+			assert.Equal(t, "String!", typeString(output["nonnull"].Type))
+			assert.Equal(t, "[String!]", typeString(output["items"].Type))
+			assert.Equal(t, "[String]", typeString(output["itemsOfPtrs"].Type))
+			input := parser.ParseInput(new(Obj)).(*graphql.InputObject).Fields()
+			assert.Equal(t, "[String!]", typeString(input["list"].Type))
+			args := parser.ParseArgs(new(Obj))
+			assert.Equal(t, "String", typeString(args["pointer"].Type))
+			assert.Equal(t, "[[Int!]]", typeString(args["matrix"].Type))
+			methods := parser.ParseOutput(Customer{}).(*graphql.Object).Fields()
+			assert.Equal(t, "String!", typeString(methods["fullName"].Type))
+			assert.Equal(t, "[String!]", typeString(methods["orders"].Type))
+			assert.Equal(t, "[Customer]", typeString(parser.Field(func() []*Customer { return nil }).Type))
+			assert.Equal(t, "Customer!", typeString(parser.Field(func() Customer { return Customer{} }).Type))
+			assert.Equal(t, "Int!", typeString(parser.SubscriptionField(func() <-chan int { return nil }).Type))
+		})
+	})
+	t.Run("name collisions", func(t *testing.T) {
+		t.Run("same type as output and input", func(t *testing.T) {
+			parser := structgraphql.NewParser()
+			parser.ParseOutput(new(User))
+			_, err := parser.TryParseInput(new(User))
+			var parseErr *structgraphql.ParseError
+			assert.ErrorAs(t, err, &parseErr)
+			assert.Contains(t, parseErr.Reason, "the object generated from structgraphql_test.User")
+		})
+		t.Run("input suffix", func(t *testing.T) {
+			parser := structgraphql.NewParser(structgraphql.WithInputSuffix("Input"))
+			assert.Equal(t, "User", parser.ParseOutput(new(User)).Name())
+			assert.Equal(t, "UserInput", parser.ParseInput(new(User)).Name())
+			assert.Equal(t, "PostInput", parser.ParseInput(new(Post)).Name())
+			_, err := structgraphql.NewSchemaBuilder(parser).Query("user", func(args struct {
+				User User `graphql:"user"`
+			}) *User {
+				return &args.User
+			}).Build()
+			assert.Nil(t, err)
+		})
+		t.Run("input name function and interface", func(t *testing.T) {
+			parser := structgraphql.NewParser(structgraphql.WithInputName(func(t reflect.Type, name string) string { return "New" + name }))
+			assert.Equal(t, "NewUser", parser.ParseInput(new(User)).Name())
+			assert.Equal(t, "CreateAccount", parser.ParseInput(new(Account)).Name())
+			assert.Equal(t, "Account", parser.ParseOutput(new(Account)).Name())
+		})
+		t.Run("distinct types with the same name", func(t *testing.T) {
+			parser := structgraphql.NewParser()
+			func() {
+				type Dup struct {
+					A string
+				}
+				parser.ParseOutput(new(Dup))
+			}()
+			type Dup struct {
+				B string
+			}
+			type Parent struct {
+				Dup Dup
+			}
+			_, err := parser.TryParseOutput(new(Parent))
+			var parseErr *structgraphql.ParseError
+			assert.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, "Parent.Dup", parseErr.Path)
+			assert.Equal(t, reflect.TypeOf(Dup{}), parseErr.Type)
+			_, err = parser.TryParseInput(new(Dup))
+			assert.ErrorAs(t, err, &parseErr)
+			assert.Panics(t, func() {
+				parser.AddScalar(Str(""), graphql.NewScalar(graphql.ScalarConfig{Name: "Dup", Serialize: graphql.String.Serialize}))
+			})
+			// names of types that failed to parse are released
+			parser.AddScalar(Str(""), graphql.NewScalar(graphql.ScalarConfig{Name: "Parent", Serialize: graphql.String.Serialize}))
+			assert.Equal(t, "Parent", parser.ParseOutput(Str("")).Name())
+		})
+	})
+	t.Run("scalars are shared by outputs and inputs", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		assert.Equal(t, graphql.String, parser.ParseInput(""))
+		assert.Equal(t, graphql.DateTime, parser.ParseInput(time.Time{}))
+		assert.Equal(t, parser.ParseOutput(Str("")), parser.ParseInput(Str("")))
+		assert.Equal(t, parser.ParseInput(ID(0)), parser.ParseOutput(ID(0)))
+	})
+	t.Run("errors", func(t *testing.T) {
+		type Product struct {
+			Price chan float64 `graphql:"price"`
+		}
+		type Item struct {
+			Product *Product `graphql:"product"`
+		}
+		type Order struct {
+			Items []Item `graphql:"items"`
+		}
+		t.Run("output", func(t *testing.T) {
+			parser := structgraphql.NewParser()
+			_, err := parser.TryParseOutput(new(Order))
+			var parseErr *structgraphql.ParseError
+			assert.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, "Order.Items[].Product.Price", parseErr.Path)
+			assert.Equal(t, reflect.TypeOf(make(chan float64)), parseErr.Type)
+			assert.Panics(t, func() { parser.ParseOutput(new(Order)) })
+			_, err = parser.TryParseOutput(new(Item))
+			assert.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, "Item.Product.Price", parseErr.Path)
+		})
+		t.Run("input", func(t *testing.T) {
+			parser := structgraphql.NewParser()
+			_, err := parser.TryParseInput(new(Order))
+			var parseErr *structgraphql.ParseError
+			assert.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, "Order.Items[].Product.Price", parseErr.Path)
+		})
+		t.Run("args", func(t *testing.T) {
+			parser := structgraphql.NewParser()
+			type Args struct {
+				Order Order `graphql:"order"`
+			}
+			_, err := parser.TryParseArgs(new(Args))
+			var parseErr *structgraphql.ParseError
+			assert.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, "Args.Order.Items[].Product.Price", parseErr.Path)
+			_, err = parser.TryParseArgs(0)
+			assert.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, reflect.TypeOf(0), parseErr.Type)
+		})
+		t.Run("retry", func(t *testing.T) {
+			parser := structgraphql.NewParser()
+			_, err := parser.TryParseOutput(Retried{})
+			assert.Error(t, err)
+			parser.AddUnion((*Outcome)(nil), RetriedNext{})
+			_, err = parser.TryParseOutput(Retried{})
+			assert.Nil(t, err)
+			schema, err := structgraphql.NewSchemaBuilder(parser).Query("retried", func() *Retried { return &Retried{Result: &RetriedNext{}} }).Build()
+			assert.Nil(t, err)
+			res := graphql.Do(graphql.Params{Schema: schema, RequestString: `{retried{next{prev{next{__typename}}} result{__typename}}}`})
+			assert.Nil(t, res.Errors)
+		})
+	})
 	t.Run("end-to-end", func(t *testing.T) {
 		parser := structgraphql.NewParser()
 		type Input struct {
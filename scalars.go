@@ -0,0 +1,418 @@
+package structgraphql
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+type Int64Mode int
+
+const (
+	// 64-bit integers are Int, which graphql limits to 32 bits
+	Int64AsInt Int64Mode = iota
+	// 64-bit integers are the Int64 scalar, serialized as strings
+	Int64AsString
+	// 64-bit integers are Float, which is exact up to 2^53
+	Int64AsFloat
+)
+
+// choose the representation of int64 and uint64, including named types of these kinds
+func WithInt64(mode Int64Mode) Option {
+	return func(parser *Parser) { parser.int64Mode = mode }
+}
+
+func (parser *Parser) getInt64Scalar() *graphql.Scalar {
+	switch parser.int64Mode {
+	case Int64AsString:
+		return Int64Scalar
+	case Int64AsFloat:
+		return graphql.Float
+	}
+	return graphql.Int
+}
+
+// register the scalars of this package for time.Duration, Date, url.URL, json.RawMessage, big.Int, big.Float and UUID
+func WithScalars() Option {
+	return func(parser *Parser) {
+		parser.AddScalar(time.Duration(0), DurationScalar)
+		parser.AddScalar(Date{}, DateScalar)
+		parser.AddScalar(url.URL{}, URLScalar)
+		parser.AddScalar(json.RawMessage{}, JSONScalar)
+		parser.AddScalar(big.Int{}, BigIntScalar)
+		parser.AddScalar(big.Float{}, DecimalScalar)
+		parser.AddScalar(UUID{}, UUIDScalar)
+	}
+}
+
+// a calendar date without time of day, serialized as 2006-01-02 by DateScalar
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+func DateOf(t time.Time) Date {
+	return Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}
+}
+
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// a UUID as 16 bytes, serialized in its canonical form by UUIDScalar
+type UUID [16]byte
+
+func (u UUID) String() string {
+	s := hex.EncodeToString(u[:])
+	return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+// arbitrary JSON values. maps are parsed as this scalar unless their fields have the entries option
+var JSONScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "JSON",
+	Description: "The `JSON` scalar type represents arbitrary JSON values.",
+	Serialize: func(value interface{}) interface{} {
+		if raw, ok := derefScalar(value).Interface().(json.RawMessage); ok {
+			var res interface{}
+			if err := json.Unmarshal(raw, &res); err != nil {
+				return nil
+			}
+			return res
+		}
+		return value
+	},
+	ParseValue:   func(value interface{}) interface{} { return value },
+	ParseLiteral: parseJSONLiteral,
+})
+
+func parseJSONLiteral(value ast.Value) interface{} {
+	switch value := value.(type) {
+	case *ast.ObjectValue:
+		res := make(map[string]interface{})
+		for _, field := range value.Fields {
+			res[field.Name.Value] = parseJSONLiteral(field.Value)
+		}
+		return res
+	case *ast.ListValue:
+		res := make([]interface{}, 0, len(value.Values))
+		for _, item := range value.Values {
+			res = append(res, parseJSONLiteral(item))
+		}
+		return res
+	case *ast.IntValue:
+		if n, err := strconv.Atoi(value.Value); err == nil {
+			return n
+		}
+		n, _ := strconv.ParseFloat(value.Value, 64)
+		return n
+	case *ast.FloatValue:
+		n, _ := strconv.ParseFloat(value.Value, 64)
+		return n
+	case *ast.StringValue:
+		return value.Value
+	case *ast.BooleanValue:
+		return value.Value
+	case *ast.EnumValue:
+		return value.Value
+	}
+	return nil
+}
+
+// 64-bit integers serialized as strings, as Int is limited to 32 bits
+var Int64Scalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Int64",
+	Description: "The `Int64` scalar type represents 64-bit integers as strings.",
+	Serialize: func(value interface{}) interface{} {
+		v := derefScalar(value)
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return strconv.FormatInt(v.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return strconv.FormatUint(v.Uint(), 10)
+		}
+		return nil
+	},
+	ParseValue: parseInt64,
+	ParseLiteral: func(value ast.Value) interface{} {
+		switch value := value.(type) {
+		case *ast.StringValue:
+			return parseInt64(value.Value)
+		case *ast.IntValue:
+			return parseInt64(value.Value)
+		}
+		return nil
+	},
+})
+
+func parseInt64(value interface{}) interface{} {
+	switch value := value.(type) {
+	case string:
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+		if n, err := strconv.ParseUint(value, 10, 64); err == nil {
+			return n
+		}
+	case int, int64, uint64:
+		return value
+	case float64:
+		// variables decoded from JSON
+		if n := int64(value); float64(n) == value {
+			return n
+		}
+	}
+	return nil
+}
+
+// arbitrary precision integers serialized as strings
+var BigIntScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "BigInt",
+	Description: "The `BigInt` scalar type represents arbitrary precision integers as strings.",
+	Serialize: func(value interface{}) interface{} {
+		if n, ok := addrScalar(value).(*big.Int); ok {
+			return n.String()
+		}
+		return nil
+	},
+	ParseValue: parseBigInt,
+	ParseLiteral: func(value ast.Value) interface{} {
+		switch value := value.(type) {
+		case *ast.StringValue:
+			return parseBigInt(value.Value)
+		case *ast.IntValue:
+			return parseBigInt(value.Value)
+		}
+		return nil
+	},
+})
+
+func parseBigInt(value interface{}) interface{} {
+	if s, ok := value.(string); ok {
+		if n, ok := new(big.Int).SetString(s, 10); ok {
+			return n
+		}
+	}
+	return nil
+}
+
+// arbitrary precision decimals serialized as strings
+var DecimalScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Decimal",
+	Description: "The `Decimal` scalar type represents arbitrary precision decimals as strings.",
+	Serialize: func(value interface{}) interface{} {
+		if n, ok := addrScalar(value).(*big.Float); ok {
+			return n.Text('f', -1)
+		}
+		return nil
+	},
+	ParseValue: parseDecimal,
+	ParseLiteral: func(value ast.Value) interface{} {
+		switch value := value.(type) {
+		case *ast.StringValue:
+			return parseDecimal(value.Value)
+		case *ast.IntValue:
+			return parseDecimal(value.Value)
+		case *ast.FloatValue:
+			return parseDecimal(value.Value)
+		}
+		return nil
+	},
+})
+
+// big.Float is binary, so it is given 4 bits per character of the decimal, more than the log2(10) a digit needs, to serialize back to the same digits
+func parseDecimal(value interface{}) interface{} {
+	if s, ok := value.(string); ok {
+		prec := uint(len(s)) * 4
+		if prec < 64 {
+			prec = 64
+		}
+		if n, _, err := big.ParseFloat(s, 10, prec, big.ToNearestEven); err == nil {
+			return n
+		}
+	}
+	return nil
+}
+
+// durations serialized in the format of time.Duration.String, e.g. 1h30m0s
+var DurationScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Duration",
+	Description: "The `Duration` scalar type represents durations as strings such as `1h30m`.",
+	Serialize: func(value interface{}) interface{} {
+		if v := derefScalar(value); v.Kind() == reflect.Int64 {
+			return time.Duration(v.Int()).String()
+		}
+		return nil
+	},
+	ParseValue: func(value interface{}) interface{} {
+		if s, ok := value.(string); ok {
+			if d, err := time.ParseDuration(s); err == nil {
+				return d
+			}
+		}
+		return nil
+	},
+	ParseLiteral: parseStringLiteral(func(s string) interface{} {
+		if d, err := time.ParseDuration(s); err == nil {
+			return d
+		}
+		return nil
+	}),
+})
+
+// dates without time of day serialized as 2006-01-02
+var DateScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Date",
+	Description: "The `Date` scalar type represents calendar dates as strings such as `2006-01-02`.",
+	Serialize: func(value interface{}) interface{} {
+		switch value := derefScalar(value).Interface().(type) {
+		case Date:
+			return value.String()
+		case time.Time:
+			return DateOf(value).String()
+		}
+		return nil
+	},
+	ParseValue: func(value interface{}) interface{} {
+		if s, ok := value.(string); ok {
+			return parseDate(s)
+		}
+		return nil
+	},
+	ParseLiteral: parseStringLiteral(parseDate),
+})
+
+func parseDate(s string) interface{} {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return DateOf(t)
+	}
+	return nil
+}
+
+// absolute URLs
+var URLScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "URL",
+	Description: "The `URL` scalar type represents absolute URLs.",
+	Serialize: func(value interface{}) interface{} {
+		if u, ok := addrScalar(value).(*url.URL); ok {
+			return u.String()
+		}
+		return nil
+	},
+	ParseValue: func(value interface{}) interface{} {
+		if s, ok := value.(string); ok {
+			return parseURL(s)
+		}
+		return nil
+	},
+	ParseLiteral: parseStringLiteral(parseURL),
+})
+
+func parseURL(s string) interface{} {
+	if u, err := url.Parse(s); err == nil && u.IsAbs() {
+		return u
+	}
+	return nil
+}
+
+// UUIDs of any 16 byte array type serialized in their canonical form
+var UUIDScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "UUID",
+	Description: "The `UUID` scalar type represents UUIDs as strings such as `123e4567-e89b-12d3-a456-426614174000`.",
+	Serialize: func(value interface{}) interface{} {
+		v := derefScalar(value)
+		if v.Kind() != reflect.Array || !v.Type().ConvertibleTo(reflect.TypeOf(UUID{})) {
+			return nil
+		}
+		return v.Convert(reflect.TypeOf(UUID{})).Interface().(UUID).String()
+	},
+	ParseValue: func(value interface{}) interface{} {
+		if s, ok := value.(string); ok {
+			return parseUUID(s)
+		}
+		return nil
+	},
+	ParseLiteral: parseStringLiteral(parseUUID),
+})
+
+func parseUUID(s string) interface{} {
+	var u UUID
+	if b, err := hex.DecodeString(strings.ReplaceAll(s, "-", "")); err == nil && len(b) == len(u) {
+		copy(u[:], b)
+		return u
+	}
+	return nil
+}
+
+// byte slices and arrays serialized in standard base64 encoding
+var Base64Scalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Base64",
+	Description: "The `Base64` scalar type represents binary data as base64 encoded strings.",
+	Serialize: func(value interface{}) interface{} {
+		v := derefScalar(value)
+		if !isBytes(v.Type()) {
+			return nil
+		}
+		b := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(b), v)
+		return base64.StdEncoding.EncodeToString(b)
+	},
+	ParseValue: func(value interface{}) interface{} {
+		if s, ok := value.(string); ok {
+			return parseBase64(s)
+		}
+		return nil
+	},
+	ParseLiteral: parseStringLiteral(parseBase64),
+})
+
+func parseBase64(s string) interface{} {
+	if b, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return b
+	}
+	return nil
+}
+
+// byte slices and arrays are Base64 rather than lists of Int
+func isBytes(t reflect.Type) bool {
+	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() == reflect.Uint8
+}
+
+func parseStringLiteral(parse func(s string) interface{}) graphql.ParseLiteralFn {
+	return func(value ast.Value) interface{} {
+		if value, ok := value.(*ast.StringValue); ok {
+			return parse(value.Value)
+		}
+		return nil
+	}
+}
+
+// the value behind any pointers
+func derefScalar(value interface{}) reflect.Value {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v
+}
+
+// a pointer to the value, as big numbers and URLs are only used through pointers
+func addrScalar(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	if v.IsValid() && v.Kind() != reflect.Ptr {
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		return ptr.Interface()
+	}
+	return value
+}
@@ -0,0 +1,141 @@
+package structgraphql_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	structgraphql "github.com/onichandame/struct-graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+type Release struct {
+	Timeout  time.Duration      `graphql:"timeout"`
+	Day      structgraphql.Date `graphql:"day"`
+	Homepage url.URL            `graphql:"homepage"`
+	Manifest json.RawMessage    `graphql:"manifest"`
+	Size     big.Int            `graphql:"size"`
+	Price    *big.Float         `graphql:"price"`
+	ID       structgraphql.UUID `graphql:"id"`
+}
+
+func TestScalars(t *testing.T) {
+	t.Run("represents 64-bit integers by option", func(t *testing.T) {
+		type Stats struct {
+			Count int64  `graphql:"count"`
+			Total uint64 `graphql:"total"`
+		}
+		assert.Equal(t, "Int!", structgraphql.NewParser().ParseOutput(Stats{}).(*graphql.Object).Fields()["count"].Type.String())
+		assert.Equal(t, "Float!", structgraphql.NewParser(structgraphql.WithInt64(structgraphql.Int64AsFloat)).ParseOutput(Stats{}).(*graphql.Object).Fields()["count"].Type.String())
+		parser := structgraphql.NewParser(structgraphql.WithInt64(structgraphql.Int64AsString))
+		stats := parser.ParseOutput(Stats{}).(*graphql.Object)
+		assert.Equal(t, structgraphql.Int64Scalar, stats.Fields()["total"].Type.(*graphql.NonNull).OfType)
+		type Args struct {
+			Count int64 `graphql:"count"`
+		}
+		schema, err := structgraphql.NewSchemaBuilder(parser).
+			Query("stats", func(args Args) Stats { return Stats{Count: args.Count + 1, Total: 1 << 63} }).
+			Build()
+		assert.Nil(t, err)
+		res := graphql.Do(graphql.Params{Schema: schema, RequestString: `{stats(count:"9007199254740993"){count total}}`})
+		assert.Nil(t, res.Errors)
+		assert.Equal(t, map[string]interface{}{"stats": map[string]interface{}{"count": "9007199254740994", "total": "9223372036854775808"}}, res.Data)
+	})
+	t.Run("registers the scalar pack by option", func(t *testing.T) {
+		parser := structgraphql.NewParser(structgraphql.WithScalars(), structgraphql.WithInputSuffix("Input"))
+		release := parser.ParseOutput(Release{}).(*graphql.Object)
+		for field, scalar := range map[string]*graphql.Scalar{
+			"timeout":  structgraphql.DurationScalar,
+			"day":      structgraphql.DateScalar,
+			"homepage": structgraphql.URLScalar,
+			"manifest": structgraphql.JSONScalar,
+			"size":     structgraphql.BigIntScalar,
+			"price":    structgraphql.DecimalScalar,
+			"id":       structgraphql.UUIDScalar,
+		} {
+			assert.Equal(t, scalar, graphql.GetNullable(release.Fields()[field].Type), field)
+		}
+		type Args struct {
+			Release Release `graphql:"release"`
+		}
+		schema, err := structgraphql.NewSchemaBuilder(parser).
+			Query("echo", func(args Args) Release { return args.Release }).
+			Build()
+		assert.Nil(t, err)
+		res := graphql.Do(graphql.Params{Schema: schema, RequestString: `{echo(release:{
+			timeout:"1h30m",
+			day:"2021-03-04",
+			homepage:"https://example.com/app",
+			manifest:{name:"app",tags:["a"]},
+			size:"123456789012345678901234567890",
+			price:"19.99",
+			id:"123e4567-e89b-12d3-a456-426614174000"
+		}){timeout day homepage manifest size price id}}`})
+		assert.Nil(t, res.Errors)
+		assert.Equal(t, map[string]interface{}{"echo": map[string]interface{}{
+			"timeout":  "1h30m0s",
+			"day":      "2021-03-04",
+			"homepage": "https://example.com/app",
+			"manifest": map[string]interface{}{"name": "app", "tags": []interface{}{"a"}},
+			"size":     "123456789012345678901234567890",
+			"price":    "19.99",
+			"id":       "123e4567-e89b-12d3-a456-426614174000",
+		}}, res.Data)
+	})
+	t.Run("keeps the digits of decimals", func(t *testing.T) {
+		for _, decimal := range []string{"12345678901234567890.123456789", "0.1", "-0.000000000000000000000000000001"} {
+			assert.Equal(t, decimal, structgraphql.DecimalScalar.Serialize(structgraphql.DecimalScalar.ParseValue(decimal)))
+			assert.Equal(t, decimal, structgraphql.DecimalScalar.Serialize(structgraphql.DecimalScalar.ParseLiteral(&ast.StringValue{Value: decimal})))
+		}
+	})
+	t.Run("parses bytes as Base64", func(t *testing.T) {
+		type Hash []byte
+		type Blob struct {
+			Content []byte   `graphql:"content"`
+			Digest  [4]byte  `graphql:"digest"`
+			Hash    Hash     `graphql:"hash"`
+			Chunks  [][]byte `graphql:"chunks"`
+		}
+		parser := structgraphql.NewParser(structgraphql.WithInputSuffix("Input"))
+		blob := parser.ParseOutput(Blob{}).(*graphql.Object)
+		assert.Equal(t, "Base64!", blob.Fields()["content"].Type.String())
+		assert.Equal(t, "Base64!", blob.Fields()["digest"].Type.String())
+		assert.Equal(t, "Hash!", blob.Fields()["hash"].Type.String())
+		assert.Equal(t, "[Base64]!", blob.Fields()["chunks"].Type.String())
+		type Args struct {
+			Blob Blob `graphql:"blob"`
+		}
+		schema, err := structgraphql.NewSchemaBuilder(parser).
+			Query("echo", func(args Args) Blob { return args.Blob }).
+			Build()
+		assert.Nil(t, err)
+		res := graphql.Do(graphql.Params{Schema: schema, RequestString: `{echo(blob:{content:"aGVsbG8=",digest:"AQIDBA==",hash:"/w==",chunks:["AA=="]}){content digest hash chunks}}`})
+		assert.Nil(t, res.Errors)
+		assert.Equal(t, map[string]interface{}{"echo": map[string]interface{}{
+			"content": "aGVsbG8=",
+			"digest":  "AQIDBA==",
+			"hash":    "/w==",
+			"chunks":  []interface{}{"AA=="},
+		}}, res.Data)
+	})
+	t.Run("respects overrides of bytes", func(t *testing.T) {
+		hex := graphql.NewScalar(graphql.ScalarConfig{Name: "Hex", Serialize: func(value interface{}) interface{} { return value }})
+		parser := structgraphql.NewParser()
+		parser.AddScalar([]byte{}, hex)
+		assert.Equal(t, hex, parser.ParseOutput([]byte{}))
+		assert.Equal(t, graphql.NewList(hex).String(), parser.ParseOutput([][]byte{}).String())
+	})
+	t.Run("rejects invalid values", func(t *testing.T) {
+		assert.Nil(t, structgraphql.UUIDScalar.ParseValue("not-a-uuid"))
+		assert.Nil(t, structgraphql.URLScalar.ParseValue("/relative"))
+		assert.Nil(t, structgraphql.DurationScalar.ParseValue("soon"))
+		assert.Nil(t, structgraphql.DateScalar.ParseValue("2021-13-01"))
+		assert.Nil(t, structgraphql.Int64Scalar.ParseValue("1.5"))
+		assert.Nil(t, structgraphql.BigIntScalar.ParseValue("1e3"))
+		assert.Nil(t, structgraphql.Base64Scalar.ParseValue("%%"))
+	})
+}
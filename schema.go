@@ -0,0 +1,139 @@
+package structgraphql
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/graphql-go/graphql"
+	goutils "github.com/onichandame/go-utils"
+)
+
+// assemble a schema from fields registered on the query, mutation and subscription roots
+type SchemaBuilder struct {
+	parser       *Parser
+	query        graphql.Fields
+	mutation     graphql.Fields
+	subscription graphql.Fields
+	err          error
+}
+
+func NewSchemaBuilder(parser *Parser) *SchemaBuilder {
+	var builder SchemaBuilder
+	builder.parser = parser
+	builder.query = make(graphql.Fields)
+	builder.mutation = make(graphql.Fields)
+	builder.subscription = make(graphql.Fields)
+	return &builder
+}
+
+// add a query from a resolver function accepted by Parser.Field or from a *graphql.Field
+func (builder *SchemaBuilder) Query(name string, fn interface{}) *SchemaBuilder {
+	builder.addField(builder.query, name, fn, builder.parser.parseRootField)
+	return builder
+}
+
+// add a mutation from a resolver function accepted by Parser.Field or from a *graphql.Field
+func (builder *SchemaBuilder) Mutation(name string, fn interface{}) *SchemaBuilder {
+	builder.addField(builder.mutation, name, fn, builder.parser.parseRootField)
+	return builder
+}
+
+// add a subscription from a function accepted by Parser.SubscriptionField or from a *graphql.Field
+func (builder *SchemaBuilder) Subscription(name string, fn interface{}) *SchemaBuilder {
+	builder.addField(builder.subscription, name, fn, builder.parser.parseSubscriptionField)
+	return builder
+}
+
+// add every exported method of resolver as a query
+func (builder *SchemaBuilder) Queries(resolver interface{}) *SchemaBuilder {
+	return builder.addMethods(resolver, builder.Query)
+}
+
+// add every exported method of resolver as a mutation
+func (builder *SchemaBuilder) Mutations(resolver interface{}) *SchemaBuilder {
+	return builder.addMethods(resolver, builder.Mutation)
+}
+
+// add every exported method of resolver as a subscription
+func (builder *SchemaBuilder) Subscriptions(resolver interface{}) *SchemaBuilder {
+	return builder.addMethods(resolver, builder.Subscription)
+}
+
+func (builder *SchemaBuilder) addMethods(resolver interface{}, add func(string, interface{}) *SchemaBuilder) *SchemaBuilder {
+	v := reflect.ValueOf(resolver)
+	for i := 0; i < v.NumMethod(); i++ {
+		add(builder.parser.getMethodName(v.Type().Method(i).Name), v.Method(i).Interface())
+	}
+	return builder
+}
+
+func (builder *SchemaBuilder) addField(fields graphql.Fields, name string, fn interface{}, parse func(reflect.Value, string) *graphql.Field) {
+	if builder.err != nil {
+		return
+	}
+	if field, ok := fn.(*graphql.Field); ok {
+		fields[name] = field
+		return
+	}
+	builder.err = goutils.Try(func() {
+		builder.parser.mu.Lock()
+		defer builder.parser.mu.Unlock()
+		defer builder.parser.rollbackOnPanic(builder.parser.trackChanges())
+		fields[name] = parse(reflect.ValueOf(fn), name)
+	})
+}
+
+func (parser *Parser) parseRootField(fn reflect.Value, path string) *graphql.Field {
+	return parser.parseField(fn, path, false)
+}
+
+// build the schema including every type the parser has generated so far
+func (builder *SchemaBuilder) Build() (graphql.Schema, error) {
+	if builder.err != nil {
+		return graphql.Schema{}, builder.err
+	}
+	// graphql-go resolves the thunks of shared types without synchronization while building a schema
+	builder.parser.mu.Lock()
+	defer builder.parser.mu.Unlock()
+	config := graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{Name: "Query", Fields: builder.query}),
+		Types: builder.parser.namedTypes(),
+	}
+	if len(builder.mutation) > 0 {
+		config.Mutation = graphql.NewObject(graphql.ObjectConfig{Name: "Mutation", Fields: builder.mutation})
+	}
+	if len(builder.subscription) > 0 {
+		config.Subscription = graphql.NewObject(graphql.ObjectConfig{Name: "Subscription", Fields: builder.subscription})
+	}
+	return graphql.NewSchema(config)
+}
+
+// every distinct output and input type generated by the parser, sorted by name
+func (parser *Parser) namedTypes() []graphql.Type {
+	var res []graphql.Type
+	seen := make(map[graphql.Type]interface{})
+	add := func(t graphql.Type) {
+		if _, ok := seen[t]; !ok {
+			seen[t] = nil
+			res = append(res, t)
+		}
+	}
+	for goType, t := range parser.types {
+		if _, ok := parser.builtins[goType]; !ok {
+			add(t)
+		}
+	}
+	for goType, t := range parser.inputs {
+		if _, ok := parser.builtins[goType]; !ok {
+			add(t)
+		}
+	}
+	for _, t := range parser.entries {
+		add(t)
+	}
+	for _, t := range parser.inputEntries {
+		add(t)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name() < res[j].Name() })
+	return res
+}
@@ -0,0 +1,85 @@
+package structgraphql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	structgraphql "github.com/onichandame/struct-graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+type Todo struct {
+	ID    ID     `graphql:"id"`
+	Title string `graphql:"title"`
+}
+
+type TodoArgs struct {
+	Title string `graphql:"title"`
+}
+
+type TodoResolver struct{ todos []*Todo }
+
+func (r *TodoResolver) Todos() []*Todo { return r.todos }
+func (r *TodoResolver) AddTodo(ctx context.Context, args TodoArgs) (*Todo, error) {
+	todo := &Todo{ID: ID(len(r.todos) + 1), Title: args.Title}
+	r.todos = append(r.todos, todo)
+	return todo, nil
+}
+
+func TestSchemaBuilder(t *testing.T) {
+	t.Run("builds roots from resolvers", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		type Orphan struct {
+			Name string `graphql:"name"`
+		}
+		parser.ParseOutput(new(Orphan))
+		resolver := new(TodoResolver)
+		schema, err := structgraphql.NewSchemaBuilder(parser).
+			Query("todos", resolver.Todos).
+			Mutation("addTodo", resolver.AddTodo).
+			Query("version", &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (interface{}, error) { return "1", nil }}).
+			Subscription("todoAdded", func(ctx context.Context) <-chan *Todo {
+				events := make(chan *Todo, 2)
+				events <- &Todo{ID: 1, Title: "first"}
+				events <- &Todo{ID: 2, Title: "second"}
+				close(events)
+				return events
+			}).
+			Build()
+		assert.Nil(t, err)
+		assert.NotNil(t, schema.Type("Orphan"))
+		res := graphql.Do(graphql.Params{Schema: schema, RequestString: `mutation{addTodo(title:"write tests"){id title}}`})
+		assert.Nil(t, res.Errors)
+		assert.Equal(t, map[string]interface{}{"addTodo": map[string]interface{}{"id": "1", "title": "write tests"}}, res.Data)
+		res = graphql.Do(graphql.Params{Schema: schema, RequestString: `{todos{title} version}`})
+		assert.Nil(t, res.Errors)
+		assert.Equal(t, map[string]interface{}{"todos": []interface{}{map[string]interface{}{"title": "write tests"}}, "version": "1"}, res.Data)
+		var titles []interface{}
+		for res := range graphql.Subscribe(graphql.Params{Schema: schema, RequestString: `subscription{todoAdded{title}}`, Context: context.Background()}) {
+			assert.Nil(t, res.Errors)
+			titles = append(titles, res.Data.(map[string]interface{})["todoAdded"].(map[string]interface{})["title"])
+		}
+		assert.Equal(t, []interface{}{"first", "second"}, titles)
+	})
+	t.Run("builds roots from methods of resolver structs", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		schema, err := structgraphql.NewSchemaBuilder(parser).Queries(&TodoResolver{todos: []*Todo{{ID: 1, Title: "todo"}}}).Build()
+		assert.Nil(t, err)
+		assert.NotNil(t, schema.QueryType().Fields()["Todos"])
+		assert.NotNil(t, schema.QueryType().Fields()["AddTodo"])
+		assert.Nil(t, schema.MutationType())
+		res := graphql.Do(graphql.Params{Schema: schema, RequestString: `{Todos{id}}`})
+		assert.Nil(t, res.Errors)
+		assert.Equal(t, map[string]interface{}{"Todos": []interface{}{map[string]interface{}{"id": "1"}}}, res.Data)
+	})
+	t.Run("reports invalid resolvers at build", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		_, err := structgraphql.NewSchemaBuilder(parser).Query("invalid", func() {}).Build()
+		var parseErr *structgraphql.ParseError
+		assert.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, "invalid", parseErr.Path)
+		_, err = structgraphql.NewSchemaBuilder(parser).Subscription("invalid", func() string { return "" }).Build()
+		assert.ErrorAs(t, err, &parseErr)
+	})
+}
@@ -0,0 +1,352 @@
+package structgraphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// print every type the parser has generated, and the types they reference, as SDL sorted by name
+func (parser *Parser) PrintSDL() string {
+	// printing resolves the thunks of the types like building a schema does
+	parser.mu.Lock()
+	defer parser.mu.Unlock()
+	return printTypes(collectTypes(parser.namedTypes()))
+}
+
+// print a built schema as SDL, including its custom directives
+func PrintSchema(schema graphql.Schema) string {
+	var blocks []string
+	directives := schema.Directives()
+	sort.Slice(directives, func(i, j int) bool { return directives[i].Name < directives[j].Name })
+	for _, directive := range directives {
+		if !isSpecifiedDirective(directive) {
+			blocks = append(blocks, printDirective(directive))
+		}
+	}
+	// the schema definition can be omitted when the roots have their conventional names
+	roots := []struct {
+		operation, name string
+		object          *graphql.Object
+	}{{"query", "Query", schema.QueryType()}, {"mutation", "Mutation", schema.MutationType()}, {"subscription", "Subscription", schema.SubscriptionType()}}
+	var operations []string
+	conventional := true
+	for _, root := range roots {
+		if root.object != nil {
+			operations = append(operations, "  "+root.operation+": "+root.object.Name())
+			conventional = conventional && root.object.Name() == root.name
+		}
+	}
+	if !conventional {
+		blocks = append(blocks, "schema {\n"+strings.Join(operations, "\n")+"\n}")
+	}
+	var types []graphql.Type
+	for name, t := range schema.TypeMap() {
+		if !strings.HasPrefix(name, "__") {
+			types = append(types, t)
+		}
+	}
+	if printed := printTypes(types); printed != "" {
+		blocks = append(blocks, strings.TrimSuffix(printed, "\n"))
+	}
+	if len(blocks) == 0 {
+		return ""
+	}
+	return strings.Join(blocks, "\n\n") + "\n"
+}
+
+func isSpecifiedDirective(directive *graphql.Directive) bool {
+	for _, specified := range graphql.SpecifiedDirectives {
+		if directive.Name == specified.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func isSpecifiedScalar(t graphql.Type) bool {
+	switch t {
+	case graphql.String, graphql.Int, graphql.Float, graphql.Boolean, graphql.ID:
+		return true
+	}
+	return false
+}
+
+// the named types reachable from roots
+func collectTypes(roots []graphql.Type) []graphql.Type {
+	var res []graphql.Type
+	seen := make(map[string]interface{})
+	var collect func(t graphql.Type)
+	collect = func(t graphql.Type) {
+		t, ok := graphql.GetNamed(t).(graphql.Type)
+		if !ok {
+			return
+		}
+		if _, ok := seen[t.Name()]; ok {
+			return
+		}
+		seen[t.Name()] = nil
+		res = append(res, t)
+		collectFields := func(fields graphql.FieldDefinitionMap) {
+			for _, field := range fields {
+				collect(field.Type)
+				for _, arg := range field.Args {
+					collect(arg.Type)
+				}
+			}
+		}
+		switch t := t.(type) {
+		case *graphql.Object:
+			for _, iface := range t.Interfaces() {
+				collect(iface)
+			}
+			collectFields(t.Fields())
+		case *graphql.Interface:
+			collectFields(t.Fields())
+		case *graphql.Union:
+			for _, member := range t.Types() {
+				collect(member)
+			}
+		case *graphql.InputObject:
+			for _, field := range t.Fields() {
+				collect(field.Type)
+			}
+		}
+	}
+	for _, t := range roots {
+		collect(t)
+	}
+	return res
+}
+
+// print named types sorted by name, leaving out the scalars defined by the specification
+func printTypes(types []graphql.Type) string {
+	sorted := append([]graphql.Type{}, types...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name() < sorted[j].Name() })
+	var blocks []string
+	for _, t := range sorted {
+		if !isSpecifiedScalar(t) {
+			blocks = append(blocks, printType(t))
+		}
+	}
+	if len(blocks) == 0 {
+		return ""
+	}
+	return strings.Join(blocks, "\n\n") + "\n"
+}
+
+func printType(t graphql.Type) string {
+	switch t := t.(type) {
+	case *graphql.Scalar:
+		return printDescription(t.Description(), "") + "scalar " + t.Name()
+	case *graphql.Enum:
+		values := append([]*graphql.EnumValueDefinition{}, t.Values()...)
+		sort.Slice(values, func(i, j int) bool { return values[i].Name < values[j].Name })
+		var lines []string
+		for _, value := range values {
+			lines = append(lines, printDescription(value.Description, "  ")+"  "+value.Name+printDeprecation(value.DeprecationReason))
+		}
+		return printDescription(t.Description(), "") + "enum " + t.Name() + printBlock(lines)
+	case *graphql.InputObject:
+		fields := t.Fields()
+		var lines []string
+		for _, name := range sortedFieldNames(fields) {
+			field := fields[name]
+			lines = append(lines, printDescription(field.Description(), "  ")+"  "+printInputValue(field.Name(), field.Type, field.DefaultValue))
+		}
+		return printDescription(t.Description(), "") + "input " + t.Name() + printBlock(lines)
+	case *graphql.Object:
+		var implements string
+		if interfaces := t.Interfaces(); len(interfaces) > 0 {
+			var names []string
+			for _, iface := range interfaces {
+				names = append(names, iface.Name())
+			}
+			implements = " implements " + strings.Join(names, " & ")
+		}
+		return printDescription(typeDescription(t), "") + "type " + t.Name() + implements + printFields(t.Fields())
+	case *graphql.Interface:
+		return printDescription(t.Description(), "") + "interface " + t.Name() + printFields(t.Fields())
+	case *graphql.Union:
+		var names []string
+		for _, member := range t.Types() {
+			names = append(names, member.Name())
+		}
+		return printDescription(t.Description(), "") + "union " + t.Name() + " = " + strings.Join(names, " | ")
+	}
+	return ""
+}
+
+func printFields(fields graphql.FieldDefinitionMap) string {
+	var lines []string
+	for _, name := range sortedFieldNames(fields) {
+		field := fields[name]
+		lines = append(lines, printDescription(field.Description, "  ")+"  "+field.Name+printArgs(field.Args, "  ")+": "+field.Type.String()+printDeprecation(field.DeprecationReason))
+	}
+	return printBlock(lines)
+}
+
+// args are printed on one line unless they have descriptions
+func printArgs(args []*graphql.Argument, indent string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	sorted := append([]*graphql.Argument{}, args...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name() < sorted[j].Name() })
+	described := false
+	for _, arg := range sorted {
+		described = described || arg.Description() != ""
+	}
+	var printed []string
+	for _, arg := range sorted {
+		if described {
+			printed = append(printed, printDescription(arg.Description(), indent+"  ")+indent+"  "+printInputValue(arg.Name(), arg.Type, arg.DefaultValue))
+		} else {
+			printed = append(printed, printInputValue(arg.Name(), arg.Type, arg.DefaultValue))
+		}
+	}
+	if described {
+		return "(\n" + strings.Join(printed, "\n") + "\n" + indent + ")"
+	}
+	return "(" + strings.Join(printed, ", ") + ")"
+}
+
+func printInputValue(name string, t graphql.Input, defaultValue interface{}) string {
+	res := name + ": " + t.String()
+	if defaultValue != nil {
+		res += " = " + printValue(defaultValue, t)
+	}
+	return res
+}
+
+func printDirective(directive *graphql.Directive) string {
+	return printDescription(directive.Description, "") + "directive @" + directive.Name + printArgs(directive.Args, "") + " on " + strings.Join(directive.Locations, " | ")
+}
+
+func printBlock(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return " {\n" + strings.Join(lines, "\n") + "\n}"
+}
+
+func printDescription(description, indent string) string {
+	if description == "" {
+		return ""
+	}
+	if !strings.Contains(description, "\n") {
+		return indent + printString(description) + "\n"
+	}
+	lines := strings.Split(strings.ReplaceAll(description, `"""`, `\"""`), "\n")
+	return indent + `"""` + "\n" + indent + strings.Join(lines, "\n"+indent) + "\n" + indent + `"""` + "\n"
+}
+
+func printDeprecation(reason string) string {
+	switch reason {
+	case "":
+		return ""
+	case graphql.DefaultDeprecationReason:
+		return " @deprecated"
+	}
+	return " @deprecated(reason: " + printString(reason) + ")"
+}
+
+func printString(s string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// print a default value as a literal of type t
+func printValue(value interface{}, t graphql.Type) string {
+	if value == nil {
+		return "null"
+	}
+	switch t := t.(type) {
+	case *graphql.NonNull:
+		return printValue(value, t.OfType)
+	case *graphql.List:
+		v := reflect.ValueOf(value)
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return printValue(value, t.OfType)
+		}
+		var items []string
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, printValue(v.Index(i).Interface(), t.OfType))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case *graphql.Enum:
+		for _, enumValue := range t.Values() {
+			if reflect.DeepEqual(enumValue.Value, value) {
+				return enumValue.Name
+			}
+		}
+	case *graphql.Scalar:
+		return printLiteral(t.Serialize(value))
+	case *graphql.InputObject:
+		if m, ok := value.(map[string]interface{}); ok {
+			fields := t.Fields()
+			var printed []string
+			for _, name := range sortedKeys(m) {
+				if field, ok := fields[name]; ok {
+					printed = append(printed, name+": "+printValue(m[name], field.Type))
+				}
+			}
+			return "{" + strings.Join(printed, ", ") + "}"
+		}
+	}
+	return printLiteral(value)
+}
+
+// print a value without a type, as serialized by a scalar
+func printLiteral(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return printString(value)
+	case bool:
+		return strconv.FormatBool(value)
+	case map[string]interface{}:
+		var printed []string
+		for _, name := range sortedKeys(value) {
+			printed = append(printed, name+": "+printLiteral(value[name]))
+		}
+		return "{" + strings.Join(printed, ", ") + "}"
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		var items []string
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, printLiteral(v.Index(i).Interface()))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+	return fmt.Sprint(value)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	var res []string
+	for key := range m {
+		res = append(res, key)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func sortedFieldNames(fields interface{}) []string {
+	var res []string
+	for _, key := range reflect.ValueOf(fields).MapKeys() {
+		res = append(res, key.String())
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,134 @@
+package structgraphql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	structgraphql "github.com/onichandame/struct-graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+type Shelf struct {
+	Name    string         `graphql:"name"`
+	Books   []*Book        `graphql:"books"`
+	Results []SearchResult `graphql:"results"`
+	Legacy  string         `graphql:"legacy,nullable" gqldeprecated:"use name"`
+}
+
+func (Shelf) GetDescription() string { return "A shelf of books.\nShelves are ordered by name." }
+
+type ShelfArgs struct {
+	First    int      `graphql:"first,default=10" gqldesc:"Number of books"`
+	Priority Priority `graphql:"priority,default=High"`
+	Tags     []string `graphql:"tags,nullable,default=[a,b]"`
+}
+
+func TestPrintSDL(t *testing.T) {
+	newParser := func() *structgraphql.Parser {
+		parser := structgraphql.NewParser()
+		parser.AddInterface((*Node)(nil), Entity{})
+		parser.AddUnion((*SearchResult)(nil), Book{}, Author{})
+		return parser
+	}
+	t.Run("prints generated types", func(t *testing.T) {
+		parser := newParser()
+		parser.ParseOutput(Shelf{})
+		parser.ParseArgs(ShelfArgs{})
+		assert.Equal(t, `type Author implements Node {
+  books: [Book]!
+  id: ID!
+  name: String!
+}
+
+type Book implements Node {
+  id: ID!
+  title: String!
+}
+
+scalar ID
+
+interface Node {
+  id: ID!
+}
+
+enum Priority {
+  "handled first"
+  High
+  Low
+  Urgent @deprecated(reason: "use High")
+}
+
+union SearchResult = Book | Author
+
+"""
+A shelf of books.
+Shelves are ordered by name.
+"""
+type Shelf {
+  books: [Book]!
+  legacy: String @deprecated(reason: "use name")
+  name: String!
+  results: [SearchResult]!
+}
+`, parser.PrintSDL())
+	})
+	t.Run("prints entries of arguments but not unused builtins", func(t *testing.T) {
+		parser := structgraphql.NewParser(structgraphql.WithScalars())
+		parser.ParseArgs(struct {
+			Labels Labels `graphql:"labels,entries"`
+		}{})
+		assert.Equal(t, `input LabelsEntryInput {
+  key: String!
+  value: String
+}
+`, parser.PrintSDL())
+		schema, err := structgraphql.NewSchemaBuilder(parser).Query("version", func() string { return "1" }).Build()
+		assert.Nil(t, err)
+		assert.NotNil(t, schema.Type("LabelsEntryInput"))
+		assert.Nil(t, schema.Type("Duration"))
+	})
+	t.Run("prints built schemas", func(t *testing.T) {
+		parser := newParser()
+		schema, err := structgraphql.NewSchemaBuilder(parser).
+			Query("shelf", func(ctx context.Context, args ShelfArgs) *Shelf { return nil }).
+			Build()
+		assert.Nil(t, err)
+		sdl := structgraphql.PrintSchema(schema)
+		assert.Contains(t, sdl, `type Query {
+  shelf(
+    "Number of books"
+    first: Int = 10
+    priority: Priority = High
+    tags: [String] = ["a", "b"]
+  ): Shelf
+}
+`)
+		assert.NotContains(t, sdl, "schema {")
+		assert.NotContains(t, sdl, "scalar String")
+		assert.NotContains(t, sdl, "__Type")
+		assert.Equal(t, sdl, structgraphql.PrintSchema(schema))
+	})
+	t.Run("prints custom directives and roots", func(t *testing.T) {
+		query := graphql.NewObject(graphql.ObjectConfig{Name: "Root", Fields: graphql.Fields{"ok": &graphql.Field{Type: graphql.Boolean}}})
+		schema, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: query,
+			Directives: append([]*graphql.Directive{graphql.NewDirective(graphql.DirectiveConfig{
+				Name:      "auth",
+				Locations: []string{graphql.DirectiveLocationField, graphql.DirectiveLocationQuery},
+				Args:      graphql.FieldConfigArgument{"role": &graphql.ArgumentConfig{Type: graphql.String}},
+			})}, graphql.SpecifiedDirectives...),
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, `directive @auth(role: String) on FIELD | QUERY
+
+schema {
+  query: Root
+}
+
+type Root {
+  ok: Boolean
+}
+`, structgraphql.PrintSchema(schema))
+	})
+}
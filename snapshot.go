@@ -0,0 +1,351 @@
+package structgraphql
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/printer"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+type ChangeLevel int
+
+const (
+	// changes that cannot break clients, e.g. an added type or field
+	ChangeSafe ChangeLevel = iota
+	// changes that may break clients relying on the previous behavior, e.g. an added enum value or a changed default
+	ChangeDangerous
+	// changes that break existing clients, e.g. a removed field or a new required argument
+	ChangeBreaking
+)
+
+func (level ChangeLevel) String() string {
+	switch level {
+	case ChangeSafe:
+		return "safe"
+	case ChangeDangerous:
+		return "dangerous"
+	}
+	return "breaking"
+}
+
+type Change struct {
+	Level ChangeLevel
+	// the changed element, e.g. User.name or Query.users(first)
+	Path        string
+	Description string
+}
+
+func (change Change) String() string {
+	return fmt.Sprintf("%v change of %v: %v", change.Level, change.Path, change.Description)
+}
+
+// the changes of at least level
+func FilterChanges(changes []Change, level ChangeLevel) []Change {
+	var res []Change
+	for _, change := range changes {
+		if change.Level >= level {
+			res = append(res, change)
+		}
+	}
+	return res
+}
+
+// compare sdl to the snapshot stored at path, e.g. in a test against the output of PrintSDL.
+// the snapshot is written instead when it does not exist yet or update is true
+func CompareSnapshot(path string, sdl string, update bool) ([]Change, error) {
+	snapshot, err := os.ReadFile(path)
+	if update || errors.Is(err, fs.ErrNotExist) {
+		return nil, os.WriteFile(path, []byte(sdl), 0644)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return DiffSDL(string(snapshot), sdl)
+}
+
+// classify the changes from the SDL document oldSDL to newSDL. changes are sorted by path and description
+func DiffSDL(oldSDL, newSDL string) ([]Change, error) {
+	oldTypes, err := parseSDL(oldSDL)
+	if err != nil {
+		return nil, err
+	}
+	newTypes, err := parseSDL(newSDL)
+	if err != nil {
+		return nil, err
+	}
+	var diff sdlDiff
+	diff.types(oldTypes, newTypes)
+	sort.Slice(diff, func(i, j int) bool {
+		if diff[i].Path != diff[j].Path {
+			return diff[i].Path < diff[j].Path
+		}
+		return diff[i].Description < diff[j].Description
+	})
+	return diff, nil
+}
+
+type sdlType struct {
+	kind   string
+	fields map[string]*sdlField
+	// the values of enums, the members of unions and the interfaces of objects, mapped to whether they are deprecated
+	names map[string]bool
+}
+
+type sdlField struct {
+	t            ast.Type
+	defaultValue interface{}
+	args         map[string]*sdlField
+	deprecated   bool
+}
+
+// the named types and directives of an SDL document. directives are keyed by their name prefixed with @
+func parseSDL(sdl string) (map[string]*sdlType, error) {
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(sdl), Name: "SDL"})})
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]*sdlType)
+	for _, definition := range doc.Definitions {
+		var name string
+		t := &sdlType{fields: make(map[string]*sdlField), names: make(map[string]bool)}
+		switch definition := definition.(type) {
+		case *ast.ScalarDefinition:
+			name, t.kind = definition.Name.Value, "scalar"
+		case *ast.ObjectDefinition:
+			name, t.kind = definition.Name.Value, "object"
+			loadSDLFields(t, definition.Fields)
+			for _, iface := range definition.Interfaces {
+				t.names[iface.Name.Value] = false
+			}
+		case *ast.InterfaceDefinition:
+			name, t.kind = definition.Name.Value, "interface"
+			loadSDLFields(t, definition.Fields)
+		case *ast.UnionDefinition:
+			name, t.kind = definition.Name.Value, "union"
+			for _, member := range definition.Types {
+				t.names[member.Name.Value] = false
+			}
+		case *ast.EnumDefinition:
+			name, t.kind = definition.Name.Value, "enum"
+			for _, value := range definition.Values {
+				t.names[value.Name.Value] = isDeprecated(value.Directives)
+			}
+		case *ast.InputObjectDefinition:
+			name, t.kind = definition.Name.Value, "input object"
+			t.fields = loadSDLInputValues(definition.Fields)
+		case *ast.DirectiveDefinition:
+			name, t.kind = "@"+definition.Name.Value, "directive"
+			t.fields[""] = &sdlField{args: loadSDLInputValues(definition.Arguments)}
+		default:
+			continue
+		}
+		res[name] = t
+	}
+	return res, nil
+}
+
+func loadSDLFields(t *sdlType, fields []*ast.FieldDefinition) {
+	for _, field := range fields {
+		t.fields[field.Name.Value] = &sdlField{t: field.Type, args: loadSDLInputValues(field.Arguments), deprecated: isDeprecated(field.Directives)}
+	}
+}
+
+func loadSDLInputValues(values []*ast.InputValueDefinition) map[string]*sdlField {
+	res := make(map[string]*sdlField)
+	for _, value := range values {
+		field := &sdlField{t: value.Type}
+		if value.DefaultValue != nil {
+			field.defaultValue = printer.Print(value.DefaultValue)
+		}
+		res[value.Name.Value] = field
+	}
+	return res
+}
+
+func isDeprecated(directives []*ast.Directive) bool {
+	for _, directive := range directives {
+		if directive.Name.Value == "deprecated" {
+			return true
+		}
+	}
+	return false
+}
+
+type sdlDiff []Change
+
+func (diff *sdlDiff) add(level ChangeLevel, path string, format string, args ...interface{}) {
+	*diff = append(*diff, Change{Level: level, Path: path, Description: fmt.Sprintf(format, args...)})
+}
+
+func (diff *sdlDiff) types(oldTypes, newTypes map[string]*sdlType) {
+	for name, oldType := range oldTypes {
+		newType, ok := newTypes[name]
+		if !ok {
+			diff.add(ChangeBreaking, name, "%v removed", oldType.kind)
+		} else if oldType.kind != newType.kind {
+			diff.add(ChangeBreaking, name, "changed from %v to %v", oldType.kind, newType.kind)
+		} else {
+			diff.typ(name, oldType, newType)
+		}
+	}
+	for name, newType := range newTypes {
+		if _, ok := oldTypes[name]; !ok {
+			diff.add(ChangeSafe, name, "%v added", newType.kind)
+		}
+	}
+}
+
+func (diff *sdlDiff) typ(name string, oldType, newType *sdlType) {
+	switch oldType.kind {
+	case "object", "interface":
+		diff.outputFields(name, oldType.fields, newType.fields)
+		diff.names(name, "interface", oldType.names, newType.names, ChangeDangerous)
+	case "input object":
+		diff.inputValues(name+".", "input field", oldType.fields, newType.fields)
+	case "directive":
+		diff.inputValues(name+"(", "argument", oldType.fields[""].args, newType.fields[""].args)
+	case "union":
+		diff.names(name, "member", oldType.names, newType.names, ChangeDangerous)
+	case "enum":
+		// clients may not handle values they did not know of
+		diff.names(name, "value", oldType.names, newType.names, ChangeDangerous)
+	}
+}
+
+// removed names are breaking and added names are of level added
+func (diff *sdlDiff) names(path, kind string, oldNames, newNames map[string]bool, added ChangeLevel) {
+	for name, oldDeprecated := range oldNames {
+		if newDeprecated, ok := newNames[name]; !ok {
+			diff.add(ChangeBreaking, path, "%v %v removed", kind, name)
+		} else if newDeprecated && !oldDeprecated {
+			diff.add(ChangeSafe, path, "%v %v deprecated", kind, name)
+		}
+	}
+	for name := range newNames {
+		if _, ok := oldNames[name]; !ok {
+			diff.add(added, path, "%v %v added", kind, name)
+		}
+	}
+}
+
+func (diff *sdlDiff) outputFields(path string, oldFields, newFields map[string]*sdlField) {
+	for name, oldField := range oldFields {
+		fieldPath := path + "." + name
+		newField, ok := newFields[name]
+		if !ok {
+			diff.add(ChangeBreaking, fieldPath, "field removed")
+			continue
+		}
+		oldType, newType := printer.Print(oldField.t), printer.Print(newField.t)
+		if oldType != newType {
+			if isSafeOutputChange(oldField.t, newField.t) {
+				// clients keep working but their handling of null becomes dead code
+				diff.add(ChangeDangerous, fieldPath, "type changed from %v to %v", oldType, newType)
+			} else {
+				diff.add(ChangeBreaking, fieldPath, "type changed from %v to %v", oldType, newType)
+			}
+		}
+		if newField.deprecated && !oldField.deprecated {
+			diff.add(ChangeSafe, fieldPath, "field deprecated")
+		}
+		diff.inputValues(fieldPath+"(", "argument", oldField.args, newField.args)
+	}
+	for name := range newFields {
+		if _, ok := oldFields[name]; !ok {
+			diff.add(ChangeSafe, path+"."+name, "field added")
+		}
+	}
+}
+
+// path is the prefix of the values, e.g. Query.users( for arguments and UserInput. for input fields
+func (diff *sdlDiff) inputValues(path, kind string, oldValues, newValues map[string]*sdlField) {
+	valuePath := func(name string) string {
+		if path[len(path)-1] == '(' {
+			return path + name + ")"
+		}
+		return path + name
+	}
+	for name, oldValue := range oldValues {
+		newValue, ok := newValues[name]
+		if !ok {
+			diff.add(ChangeBreaking, valuePath(name), "%v removed", kind)
+			continue
+		}
+		oldType, newType := printer.Print(oldValue.t), printer.Print(newValue.t)
+		if oldType != newType {
+			if isSafeInputChange(oldValue.t, newValue.t) {
+				diff.add(ChangeSafe, valuePath(name), "type changed from %v to %v", oldType, newType)
+			} else {
+				diff.add(ChangeBreaking, valuePath(name), "type changed from %v to %v", oldType, newType)
+			}
+		}
+		if oldValue.defaultValue != newValue.defaultValue {
+			diff.add(ChangeDangerous, valuePath(name), "default changed from %v to %v", printDefault(oldValue.defaultValue), printDefault(newValue.defaultValue))
+		}
+	}
+	for name, newValue := range newValues {
+		if _, ok := oldValues[name]; !ok {
+			if _, required := newValue.t.(*ast.NonNull); required && newValue.defaultValue == nil {
+				diff.add(ChangeBreaking, valuePath(name), "required %v added", kind)
+			} else {
+				diff.add(ChangeDangerous, valuePath(name), "optional %v added", kind)
+			}
+		}
+	}
+}
+
+func printDefault(value interface{}) interface{} {
+	if value == nil {
+		return "none"
+	}
+	return value
+}
+
+// outputs may only become non-null
+func isSafeOutputChange(oldType, newType ast.Type) bool {
+	switch oldType := oldType.(type) {
+	case *ast.Named:
+		if newType, ok := newType.(*ast.Named); ok {
+			return oldType.Name.Value == newType.Name.Value
+		}
+	case *ast.List:
+		if newType, ok := newType.(*ast.List); ok {
+			return isSafeOutputChange(oldType.Type, newType.Type)
+		}
+	case *ast.NonNull:
+		if newType, ok := newType.(*ast.NonNull); ok {
+			return isSafeOutputChange(oldType.Type, newType.Type)
+		}
+		return false
+	}
+	if newType, ok := newType.(*ast.NonNull); ok {
+		return isSafeOutputChange(oldType, newType.Type)
+	}
+	return false
+}
+
+// inputs may only become nullable
+func isSafeInputChange(oldType, newType ast.Type) bool {
+	switch oldType := oldType.(type) {
+	case *ast.Named:
+		if newType, ok := newType.(*ast.Named); ok {
+			return oldType.Name.Value == newType.Name.Value
+		}
+	case *ast.List:
+		if newType, ok := newType.(*ast.List); ok {
+			return isSafeInputChange(oldType.Type, newType.Type)
+		}
+	case *ast.NonNull:
+		if newType, ok := newType.(*ast.NonNull); ok {
+			return isSafeInputChange(oldType.Type, newType.Type)
+		}
+		return isSafeInputChange(oldType.Type, newType)
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package structgraphql_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	structgraphql "github.com/onichandame/struct-graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSDL(t *testing.T) {
+	diff := func(t *testing.T, oldSDL, newSDL string) []string {
+		changes, err := structgraphql.DiffSDL(oldSDL, newSDL)
+		assert.Nil(t, err)
+		var res []string
+		for _, change := range changes {
+			res = append(res, change.String())
+		}
+		return res
+	}
+	t.Run("classifies type changes", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"safe change of Added: scalar added",
+			"breaking change of Kind: changed from object to input object",
+			"breaking change of Removed: object removed",
+		}, diff(t, `scalar Kept type Removed { a: Int } type Kind { a: Int }`, `scalar Kept scalar Added input Kind { a: Int }`))
+	})
+	t.Run("classifies output field changes", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"safe change of User.added: field added",
+			"breaking change of User.loosened: type changed from String! to String",
+			"safe change of User.old: field deprecated",
+			"breaking change of User.removed: field removed",
+			"breaking change of User.retyped: type changed from Int to String",
+			"dangerous change of User.tightened: type changed from [String] to [String!]!",
+		}, diff(t, `type User {
+			removed: Int
+			retyped: Int
+			tightened: [String]
+			loosened: String!
+			old: Int
+		}`, `type User {
+			retyped: String
+			tightened: [String!]!
+			loosened: String
+			old: Int @deprecated
+			added: Int
+		}`))
+	})
+	t.Run("classifies argument and input field changes", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"breaking change of Query.users(filter): type changed from UserFilter to UserFilter!",
+			"dangerous change of Query.users(first): default changed from 10 to 20",
+			"breaking change of Query.users(role): required argument added",
+			"dangerous change of Query.users(sort): optional argument added",
+			"safe change of UserFilter.name: type changed from String! to String",
+			"breaking change of UserFilter.removed: input field removed",
+			"breaking change of UserFilter.required: required input field added",
+		}, diff(t, `type Query { users(first: Int = 10, filter: UserFilter): [Int] } input UserFilter { name: String! removed: Int }`,
+			`type Query { users(first: Int = 20, filter: UserFilter!, role: String!, sort: String): [Int] } input UserFilter { name: String required: Int! }`))
+	})
+	t.Run("classifies enum, union and interface changes", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"breaking change of Role: value GUEST removed",
+			"dangerous change of Role: value OWNER added",
+			"safe change of Role: value USER deprecated",
+			"dangerous change of Search: member Post added",
+			"breaking change of Search: member Tag removed",
+			"dangerous change of User: interface Node added",
+		}, diff(t, `enum Role { ADMIN USER GUEST } union Search = User | Tag type User { id: ID } interface Node { id: ID } type Post { id: ID } type Tag { id: ID }`,
+			`enum Role { ADMIN USER @deprecated OWNER } union Search = User | Post type User implements Node { id: ID } interface Node { id: ID } type Post { id: ID } type Tag { id: ID }`))
+	})
+	t.Run("reports invalid SDL", func(t *testing.T) {
+		_, err := structgraphql.DiffSDL(`type {`, `scalar A`)
+		assert.Error(t, err)
+	})
+}
+
+func TestCompareSnapshot(t *testing.T) {
+	type Profile struct {
+		Name  string `graphql:"name"`
+		Email string `graphql:"email,nullable"`
+	}
+	snapshot := filepath.Join(t.TempDir(), "schema.graphql")
+	parser := structgraphql.NewParser()
+	parser.ParseOutput(Profile{})
+	t.Run("writes missing snapshots", func(t *testing.T) {
+		changes, err := structgraphql.CompareSnapshot(snapshot, parser.PrintSDL(), false)
+		assert.Nil(t, err)
+		assert.Empty(t, changes)
+		written, err := os.ReadFile(snapshot)
+		assert.Nil(t, err)
+		assert.Equal(t, parser.PrintSDL(), string(written))
+	})
+	t.Run("compares to snapshots", func(t *testing.T) {
+		type Renamed struct {
+			FullName string `graphql:"fullName"`
+			Email    string `graphql:"email"`
+		}
+		parser := structgraphql.NewParser(structgraphql.WithTypeName(func(t reflect.Type, name string) string { return "Profile" }))
+		parser.ParseOutput(Renamed{})
+		changes, err := structgraphql.CompareSnapshot(snapshot, parser.PrintSDL(), false)
+		assert.Nil(t, err)
+		assert.Equal(t, []structgraphql.Change{
+			{Level: structgraphql.ChangeBreaking, Path: "Profile.name", Description: "field removed"},
+		}, structgraphql.FilterChanges(changes, structgraphql.ChangeBreaking))
+		assert.Len(t, structgraphql.FilterChanges(changes, structgraphql.ChangeDangerous), 2)
+		assert.Len(t, changes, 3)
+	})
+	t.Run("updates snapshots", func(t *testing.T) {
+		_, err := structgraphql.CompareSnapshot(snapshot, "scalar Updated\n", true)
+		assert.Nil(t, err)
+		changes, err := structgraphql.CompareSnapshot(snapshot, "scalar Updated\n", false)
+		assert.Nil(t, err)
+		assert.Empty(t, changes)
+	})
+}
@@ -0,0 +1,70 @@
+package structgraphql
+
+import (
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+	goutils "github.com/onichandame/go-utils"
+)
+
+// register the Go interface pointed to by ent, e.g. (*SearchResult)(nil), as a union of the objects parsed from members.
+// fields of the interface type then resolve to the member whose Go type matches the resolved value
+func (parser *Parser) AddUnion(ent interface{}, members ...interface{}) *graphql.Union {
+	res, err := parser.TryAddUnion(ent, members...)
+	goutils.Assert(err)
+	return res
+}
+
+// same as AddUnion but returns a *ParseError instead of panicking
+func (parser *Parser) TryAddUnion(ent interface{}, members ...interface{}) (res *graphql.Union, err error) {
+	defer goutils.RecoverToErr(&err)
+	parser.mu.Lock()
+	defer parser.mu.Unlock()
+	defer parser.rollbackOnPanic(parser.trackChanges())
+	t := getType(ent)
+	return parser.parseUnion(t, rootPath(t), members...), nil
+}
+
+func (parser *Parser) parseUnion(t reflect.Type, path string, members ...interface{}) *graphql.Union {
+	if t.Kind() != reflect.Interface {
+		panic(newParseError(t, path, "unions must be Go interfaces"))
+	}
+	if union, ok := parser.types[t].(*graphql.Union); ok {
+		return union
+	}
+	if len(members) == 0 {
+		panic(newParseError(t, path, "unions must have members"))
+	}
+	name := parser.getTypeName(t)
+	if name == "" {
+		panic(newParseError(t, path, "unions of unnamed types must be named by WithTypeName"))
+	}
+	// graphql-go takes the members of a union at creation, so the union is registered empty and filled in once its members are parsed
+	union := &graphql.Union{PrivateName: name}
+	parser.registerName(t, union, path)
+	parser.setType(t, union)
+	var objects []*graphql.Object
+	objectsByType := make(map[reflect.Type]*graphql.Object)
+	for _, member := range members {
+		memberType := getType(member)
+		memberPath := path + "|" + rootPath(memberType)
+		if !reflect.PtrTo(memberType).Implements(t) {
+			panic(newParseError(t, memberPath, "%v does not implement %v", memberType, t))
+		}
+		object, ok := parser.parseOutput(memberType, memberPath).(*graphql.Object)
+		if !ok {
+			panic(newParseError(t, memberPath, "union members must be structs"))
+		}
+		objects = append(objects, object)
+		objectsByType[memberType] = object
+	}
+	*union = *graphql.NewUnion(graphql.UnionConfig{
+		Name:        name,
+		Description: getDescription(t),
+		Types:       objects,
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			return objectsByType[getType(reflect.TypeOf(p.Value))]
+		},
+	})
+	return union
+}
@@ -0,0 +1,95 @@
+package structgraphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	structgraphql "github.com/onichandame/struct-graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+type SearchResult interface{ isSearchResult() }
+
+func (*Book) isSearchResult()   {}
+func (*Author) isSearchResult() {}
+
+type SearchResolver struct{ results []SearchResult }
+
+func (r *SearchResolver) Search() []SearchResult { return r.results }
+
+type FileNode interface{ isFileNode() }
+
+type File struct {
+	Name string `graphql:"name"`
+}
+
+type Folder struct {
+	Name     string     `graphql:"name"`
+	Children []FileNode `graphql:"children"`
+}
+
+func (*File) isFileNode()   {}
+func (*Folder) isFileNode() {}
+
+func TestUnion(t *testing.T) {
+	t.Run("resolves members by Go type", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		union := parser.AddUnion((*SearchResult)(nil), Book{}, Author{})
+		assert.Equal(t, "SearchResult", union.Name())
+		assert.Len(t, union.Types(), 2)
+		assert.Equal(t, union, parser.ParseOutput((*SearchResult)(nil)))
+		resolver := &SearchResolver{results: []SearchResult{&Book{Title: "Dune"}, &Author{Name: "Herbert"}}}
+		schema, err := structgraphql.NewSchemaBuilder(parser).Queries(resolver).Build()
+		assert.Nil(t, err)
+		res := graphql.Do(graphql.Params{Schema: schema, RequestString: `{Search{__typename ... on Book{title} ... on Author{name}}}`})
+		assert.Nil(t, res.Errors)
+		assert.Equal(t, map[string]interface{}{"Search": []interface{}{
+			map[string]interface{}{"__typename": "Book", "title": "Dune"},
+			map[string]interface{}{"__typename": "Author", "name": "Herbert"},
+		}}, res.Data)
+	})
+	t.Run("supports members referencing the union", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		union := parser.AddUnion((*FileNode)(nil), Folder{}, File{})
+		folder := parser.ParseOutput(Folder{}).(*graphql.Object)
+		assert.Equal(t, union, folder.Fields()["children"].Type.(*graphql.NonNull).OfType.(*graphql.List).OfType)
+		assert.Nil(t, union.Error())
+	})
+	t.Run("names unnamed interfaces by WithTypeName", func(t *testing.T) {
+		type Any = interface{}
+		parser := structgraphql.NewParser(structgraphql.WithTypeName(func(t reflect.Type, name string) string {
+			if name == "" {
+				return "Any"
+			}
+			return name
+		}))
+		union := parser.AddUnion((*Any)(nil), File{})
+		assert.Equal(t, "Any", union.Name())
+		_, err := structgraphql.NewParser().TryAddUnion((*Any)(nil), File{})
+		assert.Error(t, err)
+	})
+	t.Run("rejects invalid members", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		_, err := parser.TryAddUnion((*SearchResult)(nil), File{})
+		assert.Error(t, err)
+		_, err = parser.TryAddUnion((*SearchResult)(nil))
+		assert.Error(t, err)
+		_, err = parser.TryAddUnion(File{}, File{})
+		assert.Error(t, err)
+		assert.Nil(t, parser.ParseOutput(Book{}).(*graphql.Object).Error())
+		assert.NotPanics(t, func() { parser.AddUnion((*SearchResult)(nil), Book{}) })
+	})
+	t.Run("cannot be an input", func(t *testing.T) {
+		parser := structgraphql.NewParser()
+		parser.AddUnion((*SearchResult)(nil), Book{}, Author{})
+		type Hit struct {
+			Result SearchResult `graphql:"result"`
+		}
+		_, err := parser.TryParseInput(Hit{})
+		var parseErr *structgraphql.ParseError
+		assert.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, "Hit.Result", parseErr.Path)
+		assert.Contains(t, err.Error(), "the union SearchResult cannot be an input")
+	})
+}
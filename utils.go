@@ -19,6 +19,9 @@ func getName(t reflect.Type) string {
 	return name
 }
 
+// name the input object generated from a struct differently from its output object
+type InputNamed interface{ GetInputName() string }
+
 type Described interface{ GetDescription() string }
 
 func getDescription(t reflect.Type) string {
@@ -30,6 +33,27 @@ func getDescription(t reflect.Type) string {
 	return description
 }
 
+// the description of a named graphql type. graphql-go does not return the description of objects from Description
+func typeDescription(t graphql.Type) string {
+	if object, ok := t.(*graphql.Object); ok {
+		return object.PrivateDescription
+	}
+	return t.Description()
+}
+
+// the description tag of a field takes precedence over the description of its type
+func getFieldDescription(field *reflect.StructField, t reflect.Type) string {
+	if description, ok := field.Tag.Lookup(TAG_DESCRIPTION); ok {
+		return description
+	}
+	return getDescription(t)
+}
+
+// only output fields can be deprecated as graphql-go has no deprecation for input fields and arguments, where the tag is ignored
+func getDeprecationReason(field *reflect.StructField) string {
+	return field.Tag.Get(TAG_DEPRECATED)
+}
+
 func getType(ent interface{}) reflect.Type {
 	if t, ok := ent.(reflect.Type); ok {
 		return goutils.UnwrapType(t)
@@ -38,17 +62,20 @@ func getType(ent interface{}) reflect.Type {
 	}
 }
 
-func decorateFieldType(field *reflect.StructField, t graphql.Type) graphql.Type {
-	tags, _ := structtag.Parse(string(field.Tag))
-	if tags != nil {
-		tag, _ := tags.Get(TAG_PREFIX)
-		if tag != nil {
-			if !tag.HasOption(TAG_NULLABLE) {
-				t = graphql.NewNonNull(t)
+// unexported fields and fields tagged with graphql:"-" are omitted. gqloutput:"-" and gqlinput:"-" omit a field from outputs or inputs only
+func isOmitted(field *reflect.StructField, sideTag string) bool {
+	// the exported fields of an unexported embedded struct are still promoted
+	if field.PkgPath != "" && !(field.Anonymous && field.Type.Kind() == reflect.Struct) {
+		return true
+	}
+	if tags, _ := structtag.Parse(string(field.Tag)); tags != nil {
+		for _, key := range []string{TAG_PREFIX, sideTag} {
+			if tag, _ := tags.Get(key); tag != nil && tag.Name == TAG_OMIT && len(tag.Options) == 0 {
+				return true
 			}
 		}
 	}
-	return t
+	return false
 }
 
 type Defaulted interface {
@@ -63,33 +90,73 @@ func getDefault(t reflect.Type) interface{} {
 	return res
 }
 
-func getFieldName(field *reflect.StructField) string {
-	name := field.Name
+// the name given by the tag of a field, empty if the field is not named by its tag
+func getTagName(field *reflect.StructField) string {
 	tags, _ := structtag.Parse(string(field.Tag))
 	if tags != nil {
 		tag, _ := tags.Get(TAG_PREFIX)
 		if tag != nil {
-			if tag.Name != "" {
-				name = tag.Name
-			}
+			return tag.Name
 		}
 	}
-	return name
+	return ""
 }
 
-func unwrapSlice(t reflect.Type, opts ...interface{}) (reflect.Type, int) {
-	var dim int
-	if len(opts) > 0 {
-		if d, ok := opts[0].(int); ok {
-			dim = d
-		}
+// expose methods as fields. keys are field names and values are method names
+type Computed interface {
+	GetMethods() map[string]string
+}
+
+func getMethods(t reflect.Type) map[string]string {
+	var res map[string]string
+	if computed, ok := reflect.New(t).Interface().(Computed); ok {
+		res = computed.GetMethods()
 	}
-	if t.Kind() == reflect.Slice {
-		dim++
-		return unwrapSlice(t.Elem(), dim)
-	} else {
-		return t, dim
+	return res
+}
+
+// generate an enum from a type listing its values, e.g. the constants of a Go enum. keys are the names of the values
+type Enumerated interface {
+	GetValues() map[string]interface{}
+}
+
+func isEnumerated(t reflect.Type) bool {
+	_, ok := reflect.New(t).Interface().(Enumerated)
+	return ok
+}
+
+func getValues(t reflect.Type) map[string]interface{} {
+	var res map[string]interface{}
+	if enumerated, ok := reflect.New(t).Interface().(Enumerated); ok {
+		res = enumerated.GetValues()
+	}
+	return res
+}
+
+// describe the values of an enum. keys are the names of the values before naming strategies apply
+type ValuesDescribed interface {
+	GetValueDescriptions() map[string]string
+}
+
+func getValueDescriptions(t reflect.Type) map[string]string {
+	var res map[string]string
+	if described, ok := reflect.New(t).Interface().(ValuesDescribed); ok {
+		res = described.GetValueDescriptions()
+	}
+	return res
+}
+
+// deprecate values of an enum. keys are the names of the values before naming strategies apply and values are the reasons
+type ValuesDeprecated interface {
+	GetValueDeprecations() map[string]string
+}
+
+func getValueDeprecations(t reflect.Type) map[string]string {
+	var res map[string]string
+	if deprecated, ok := reflect.New(t).Interface().(ValuesDeprecated); ok {
+		res = deprecated.GetValueDeprecations()
 	}
+	return res
 }
 
 type ID interface {